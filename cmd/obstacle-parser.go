@@ -0,0 +1,120 @@
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"maps"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/seanpk/go-for-rays/internal/geometry"
+	"github.com/seanpk/go-for-rays/internal/projectile"
+)
+
+// parseObstacles reads an obstacle description with one obstacle per line, in the format
+//
+//	ground point=(x,y,z) normal=(x,y,z) [restitution=r]
+//	plane  point=(x,y,z) normal=(x,y,z) [restitution=r] [name=n]
+//	box    min=(x,y,z) max=(x,y,z) [restitution=r] [name=n]
+//
+// Blank lines and lines starting with '#' are ignored.
+// A ground replaces the default flat ground at z=0; hitting it counts as landing.
+// Errors are reported with the line number they occur on.
+func parseObstacles(r io.Reader) ([]projectile.Obstacle, error) {
+	var obstacles []projectile.Obstacle
+	scanner := bufio.NewScanner(r)
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+
+		obstacle, err := parseObstacleLine(text, len(obstacles))
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %v", line, err)
+		}
+		obstacles = append(obstacles, obstacle)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return obstacles, nil
+}
+
+var obstacleAttributeMatcher = regexp.MustCompile(`(\w+)\s*=\s*(\([^)]*\)|[^\s(]+)`)
+
+func parseObstacleLine(text string, index int) (projectile.Obstacle, error) {
+	kind, rest, _ := strings.Cut(text, " ")
+
+	attributes := map[string]string{}
+	for _, match := range obstacleAttributeMatcher.FindAllStringSubmatch(rest, -1) {
+		attributes[match[1]] = match[2]
+	}
+
+	point := func(key string) (geometry.HomogeneousTuple, error) {
+		value, ok := attributes[key]
+		if !ok {
+			return geometry.NaNTuple(), fmt.Errorf("%s requires %s=(x,y,z)", kind, key)
+		}
+		delete(attributes, key)
+		tuple, err := parseTuple(value, parseTupleOptions{dimensions: 3, kind: "Point"})
+		if err != nil {
+			return geometry.NaNTuple(), fmt.Errorf("invalid %s: %v", key, err)
+		}
+		return tuple, nil
+	}
+
+	obstacle := projectile.Obstacle{Name: fmt.Sprintf("%s #%d", kind, index+1)}
+	if name, ok := attributes["name"]; ok {
+		obstacle.Name = name
+		delete(attributes, "name")
+	}
+	if value, ok := attributes["restitution"]; ok {
+		restitution, err := strconv.ParseFloat(value, 64)
+		if err != nil || restitution < 0 || restitution > 1 {
+			return projectile.Obstacle{}, fmt.Errorf("invalid restitution: %q must be a number from 0 to 1", value)
+		}
+		obstacle.Restitution = restitution
+		delete(attributes, "restitution")
+	}
+
+	switch kind {
+	case "ground", "plane":
+		origin, err := point("point")
+		if err != nil {
+			return projectile.Obstacle{}, err
+		}
+		normal, err := point("normal")
+		if err != nil {
+			return projectile.Obstacle{}, err
+		}
+		if geometry.ToVector(normal).Magnitude() == 0 {
+			return projectile.Obstacle{}, fmt.Errorf("invalid normal: must not be zero")
+		}
+		obstacle.Surface = projectile.NewPlane(origin, geometry.NewVector(normal.X(), normal.Y(), normal.Z()))
+		if kind == "ground" {
+			obstacle.Name, obstacle.Ground = "ground", true
+		}
+	case "box":
+		min, err := point("min")
+		if err != nil {
+			return projectile.Obstacle{}, err
+		}
+		max, err := point("max")
+		if err != nil {
+			return projectile.Obstacle{}, err
+		}
+		obstacle.Surface = projectile.NewBox(min, max)
+	default:
+		return projectile.Obstacle{}, fmt.Errorf("unknown obstacle %q: expected ground, plane or box", kind)
+	}
+
+	if len(attributes) > 0 {
+		keys := slices.Sorted(maps.Keys(attributes))
+		return projectile.Obstacle{}, fmt.Errorf("unknown attribute %q for %s", keys[0], kind)
+	}
+	return obstacle, nil
+}
@@ -0,0 +1,54 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseObstacles(t *testing.T) {
+	input := `
+# a sloped ground with a wall
+ground point=(0,0,0) normal=(-0.1, 0, 1) restitution=0.3
+box min=(10,-5,0) max=(12,5,8) name=wall
+plane point=(0,20,0) normal=(0,-1,0)
+`
+	obstacles, err := parseObstacles(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(obstacles) != 3 {
+		t.Fatalf("expected 3 obstacles, got %d", len(obstacles))
+	}
+	if o := obstacles[0]; o.Name != "ground" || !o.Ground || o.Restitution != 0.3 {
+		t.Errorf("expected a bouncy ground, got %+v", o)
+	}
+	if o := obstacles[1]; o.Name != "wall" || o.Ground || o.Restitution != 0 {
+		t.Errorf("expected a wall, got %+v", o)
+	}
+	if o := obstacles[2]; o.Name != "plane #3" {
+		t.Errorf("expected a default name, got %q", o.Name)
+	}
+}
+
+func TestParseObstaclesErrors(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		error string
+	}{
+		{name: "unknown kind", input: "sphere center=(0,0,0)", error: "line 1: unknown obstacle"},
+		{name: "missing attribute", input: "\nbox min=(0,0,0)", error: "line 2: box requires max=(x,y,z)"},
+		{name: "invalid tuple", input: "plane point=(0,0) normal=(0,0,1)", error: "line 1: invalid point"},
+		{name: "invalid restitution", input: "box min=(0,0,0) max=(1,1,1) restitution=2", error: "line 1: invalid restitution"},
+		{name: "unknown attribute", input: "box min=(0,0,0) max=(1,1,1) color=red", error: "line 1: unknown attribute"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := parseObstacles(strings.NewReader(tt.input))
+			if err == nil || !strings.HasPrefix(err.Error(), tt.error) {
+				t.Errorf("expected error starting with %q, got %v", tt.error, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,88 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"text/tabwriter"
+
+	"github.com/seanpk/go-for-rays/internal/projectile"
+	"github.com/spf13/cobra"
+)
+
+// projectileAimCmd represents the projectile aim command
+var projectileAimCmd = &cobra.Command{
+	Use:   "aim",
+	Short: "Find the launch that hits a target point",
+	Long: `This command searches for launches from the launch point that land on the target point, taking gravity, wind and drag into account.
+With --speed, it reports every elevation that hits the target at that speed (usually a low and a high arc).
+Otherwise, it reports the speed needed to hit the target at the given --elevation.
+The azimuth is corrected for cross winds in both cases.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		targetStr, _ := cmd.Flags().GetString("target")
+		speed, _ := cmd.Flags().GetFloat64("speed")
+		elevation, _ := cmd.Flags().GetFloat64("elevation")
+		tolerance, _ := cmd.Flags().GetFloat64("tolerance")
+
+		setup, err := parseSimulationFlags(cmd)
+		if err != nil {
+			return err
+		}
+
+		target, err := parseTuple(targetStr, parseTupleOptions{dimensions: 3, kind: "Point"})
+		if err != nil {
+			return fmt.Errorf("invalid target: %v", err)
+		}
+
+		options := projectile.AimOptions{Simulation: setup.options, Tolerance: tolerance}
+
+		var solutions []projectile.AimSolution
+		if cmd.Flags().Changed("speed") {
+			solutions, err = projectile.AimAtSpeed(setup.env, setup.launchPoint, target, speed, options)
+		} else {
+			var solution projectile.AimSolution
+			solution, err = projectile.AimAtElevation(setup.env, setup.launchPoint, target, elevation*math.Pi/180, options)
+			solutions = append(solutions, solution)
+		}
+		if err != nil {
+			return err
+		}
+
+		printAimSolutions(cmd.OutOrStdout(), solutions)
+		return nil
+	},
+}
+
+// printAimSolutions writes one row per solution, labelling the arcs when there is more than one.
+func printAimSolutions(w io.Writer, solutions []projectile.AimSolution) {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', tabwriter.AlignRight)
+	fmt.Fprintln(tw, "Arc\tElevation\tAzimuth\tSpeed\tVelocity\tFlight Time\tMiss\t")
+	for i, s := range solutions {
+		arc := "-"
+		if len(solutions) > 1 {
+			switch i {
+			case 0:
+				arc = "low"
+			case len(solutions) - 1:
+				arc = "high"
+			default:
+				arc = fmt.Sprintf("#%d", i+1)
+			}
+		}
+		fmt.Fprintf(tw, "%s\t%.3f\t%.3f\t%.3f\t(%.3f, %.3f, %.3f)\t%.3f\t%.2g\t\n",
+			arc,
+			s.Elevation*180/math.Pi, s.Azimuth*180/math.Pi, s.Speed,
+			s.Velocity.X(), s.Velocity.Y(), s.Velocity.Z(),
+			s.FlightTime, s.Miss)
+	}
+	tw.Flush()
+}
+
+func init() {
+	projectileCmd.AddCommand(projectileAimCmd)
+
+	projectileAimCmd.Flags().String("target", "", "Point the projectile should land on (x,y,z)")
+	projectileAimCmd.Flags().Float64("speed", 0, "Fixed launch speed; solve for the elevation")
+	projectileAimCmd.Flags().Float64("elevation", 45, "Fixed elevation in degrees when no speed is given; solve for the speed")
+	projectileAimCmd.Flags().Float64("tolerance", 1e-3, "Acceptable distance between the impact and the target")
+}
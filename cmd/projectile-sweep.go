@@ -0,0 +1,111 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"slices"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/seanpk/go-for-rays/internal/projectile"
+	"github.com/spf13/cobra"
+)
+
+// projectileSweepCmd represents the projectile sweep command
+var projectileSweepCmd = &cobra.Command{
+	Use:   "sweep",
+	Short: "Simulate a range of launch angles and speeds",
+	Long: `This command simulates a launch for every combination of elevation, azimuth and speed, in parallel.
+It reports the results as a table sorted by range, apex or flight time, and highlights the best configuration.
+Ranges are given as start:end:step or as a single value; angles are in degrees.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		elevationStr, _ := cmd.Flags().GetString("elevation")
+		azimuthStr, _ := cmd.Flags().GetString("azimuth")
+		speedStr, _ := cmd.Flags().GetString("speed")
+		sortKey, _ := cmd.Flags().GetString("sort")
+		workers, _ := cmd.Flags().GetInt("workers")
+
+		// checked before simulating, since a large sweep takes a while
+		if !slices.Contains(projectile.SweepSortKeys(), sortKey) {
+			return fmt.Errorf("invalid sort: unknown sort key %q: expected one of %s", sortKey, strings.Join(projectile.SweepSortKeys(), ", "))
+		}
+
+		setup, err := parseSimulationFlags(cmd)
+		if err != nil {
+			return err
+		}
+
+		elevations, err := parseRange(elevationStr)
+		if err != nil {
+			return fmt.Errorf("invalid elevation: %v", err)
+		}
+
+		azimuths, err := parseRange(azimuthStr)
+		if err != nil {
+			return fmt.Errorf("invalid azimuth: %v", err)
+		}
+
+		speeds, err := parseRange(speedStr)
+		if err != nil {
+			return fmt.Errorf("invalid speed: %v", err)
+		}
+
+		var cases []projectile.SweepCase
+		for _, elevation := range elevations {
+			for _, azimuth := range azimuths {
+				for _, speed := range speeds {
+					cases = append(cases, projectile.SweepCase{
+						Elevation: elevation * math.Pi / 180,
+						Azimuth:   azimuth * math.Pi / 180,
+						Speed:     speed,
+					})
+				}
+			}
+		}
+
+		results := projectile.Sweep(setup.env, setup.launchPoint, cases, setup.options, workers)
+		if err := projectile.SortSweepResults(results, sortKey); err != nil {
+			return fmt.Errorf("invalid sort: %v", err)
+		}
+
+		printSweepResults(cmd.OutOrStdout(), results)
+		return nil
+	},
+}
+
+// printSweepResults writes one row per result, marking the first (best) row with an asterisk.
+func printSweepResults(w io.Writer, results []projectile.SweepResult) {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', tabwriter.AlignRight)
+	fmt.Fprintln(tw, "\tElevation\tAzimuth\tSpeed\tRange\tApex\tFlight Time\tLanded\t")
+	for i, r := range results {
+		marker := ""
+		if i == 0 && r.Landed {
+			marker = "*"
+		}
+		fmt.Fprintf(tw, "%s\t%.2f\t%.2f\t%.3f\t%.3f\t%.3f\t%.3f\t%v\t\n",
+			marker,
+			r.Elevation*180/math.Pi, r.Azimuth*180/math.Pi, r.Speed,
+			r.Range, r.Apex.Z(), r.FlightTime, r.Landed)
+	}
+	tw.Flush()
+
+	if len(results) == 0 || !results[0].Landed {
+		fmt.Fprintf(w, "\nBest: none of the %d launches landed\n", len(results))
+		return
+	}
+	best := results[0]
+	fmt.Fprintf(w, "\nBest: elevation=%.2f, azimuth=%.2f, speed=%.3f (range=%.3f, apex=%.3f, flight time=%.3f)\n",
+		best.Elevation*180/math.Pi, best.Azimuth*180/math.Pi, best.Speed,
+		best.Range, best.Apex.Z(), best.FlightTime)
+}
+
+func init() {
+	projectileCmd.AddCommand(projectileSweepCmd)
+
+	projectileSweepCmd.Flags().String("elevation", "0:90:5", "Elevation angles above the horizon, in degrees (start:end:step)")
+	projectileSweepCmd.Flags().String("azimuth", "0", "Azimuth angles from the x axis, in degrees (start:end:step)")
+	projectileSweepCmd.Flags().String("speed", "10", "Launch speeds (start:end:step)")
+	projectileSweepCmd.Flags().String("sort", "range", "Sort the results by: "+strings.Join(projectile.SweepSortKeys(), ", "))
+	projectileSweepCmd.Flags().Int("workers", 0, "Number of simulations to run in parallel (default: number of CPUs)")
+}
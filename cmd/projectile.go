@@ -5,8 +5,16 @@ package cmd
 
 import (
 	"fmt"
+	"io"
+	"math"
+	"os"
+	"slices"
+	"strings"
+	"text/tabwriter"
 
+	"github.com/seanpk/go-for-rays/internal/canvas"
 	"github.com/seanpk/go-for-rays/internal/geometry"
+	"github.com/seanpk/go-for-rays/internal/projectile"
 	"github.com/spf13/cobra"
 )
 
@@ -15,16 +23,20 @@ var projectileCmd = &cobra.Command{
 	Use:   "projectile",
 	Short: "A projectile simulation",
 	Long: `This command simulates the motion of a projectile under the influence of gravity and wind.
-It provides options to configure the initial location and velocity, and the environmental factors affecting the projectile's trajectory.`,
+It provides options to configure the initial location and velocity, and the environmental factors affecting the projectile's trajectory.
+When a drag coefficient and cross-section area are given, air resistance is modeled and the wind is the velocity of the air.`,
 	RunE: func(cmd *cobra.Command, args []string) error {
-		launchPointStr, _ := cmd.Flags().GetString("launch-point")
 		velocityStr, _ := cmd.Flags().GetString("velocity")
-		windStr, _ := cmd.Flags().GetString("wind")
-		gravity, _ := cmd.Flags().GetFloat64("gravity")
+		format, _ := cmd.Flags().GetString("format")
+		outputPath, _ := cmd.Flags().GetString("output")
+		plotPath, _ := cmd.Flags().GetString("plot")
+		plotWidth, _ := cmd.Flags().GetInt("plot-width")
+		plotHeight, _ := cmd.Flags().GetInt("plot-height")
+		plotFormat, _ := cmd.Flags().GetString("plot-format")
 
-		launchPoint, err := parseTuple(launchPointStr, parseTupleOptions{dimensions: 3, kind: "Point"})
+		setup, err := parseSimulationFlags(cmd)
 		if err != nil {
-			return fmt.Errorf("invalid launch point: %v", err)
+			return err
 		}
 
 		velocity, err := parseTuple(velocityStr, parseTupleOptions{dimensions: 3, kind: "Vector"})
@@ -32,28 +44,242 @@ It provides options to configure the initial location and velocity, and the envi
 			return fmt.Errorf("invalid velocity: %v", err)
 		}
 
-		wind, err := parseTuple(windStr, parseTupleOptions{dimensions: 2, kind: "Vector"})
-		if err != nil {
-			return fmt.Errorf("invalid wind: %v", err)
+		if format != "text" && !slices.Contains(projectile.ExportFormats, format) {
+			return fmt.Errorf("invalid format: %q is not one of text, %s", format, strings.Join(projectile.ExportFormats, ", "))
 		}
 
-		gravityVector := geometry.NewVector(0, 0, -gravity)
+		if plotPath != "" {
+			if plotWidth <= 0 || plotHeight <= 0 {
+				return fmt.Errorf("invalid plot size: %dx%d must be positive", plotWidth, plotHeight)
+			}
+			if plotFormat == "" {
+				plotFormat = canvas.FormatForPath(plotPath)
+			}
+			if !slices.Contains(canvas.Formats, plotFormat) {
+				return fmt.Errorf("invalid plot format: %q is not one of %s", plotFormat, strings.Join(canvas.Formats, ", "))
+			}
+		}
 
-		fmt.Printf("Projectile Simulation:\n")
-		fmt.Printf("\tLaunch Point: %s\n", launchPoint.String())
-		fmt.Printf("\tVelocity    : %s\n", velocity.String())
-		fmt.Printf("\tWind        : %s\n", wind.String())
-		fmt.Printf("\tGravity     : %s\n", gravityVector.String())
+		env, options := setup.env, setup.options
+		launch := projectile.Projectile{Position: setup.launchPoint, Velocity: velocity}
+		trajectory := projectile.Simulate(env, launch, options)
+		params := projectile.NewLaunchParameters(env, launch, setup.integratorName, options)
 
-		return nil
+		if plotPath != "" {
+			plot := projectile.Plot(trajectory, plotWidth, plotHeight)
+			err := writeOutput(nil, plotPath, func(w io.Writer) error {
+				return plot.Write(w, plotFormat)
+			})
+			if err != nil {
+				return fmt.Errorf("cannot write plot: %v", err)
+			}
+		}
+
+		return writeOutput(cmd.OutOrStdout(), outputPath, func(w io.Writer) error {
+			if format == "text" {
+				return printReport(w, params, env, options.Obstacles, trajectory)
+			}
+			return projectile.Export(w, format, params, trajectory)
+		})
 	},
 }
 
+// simulationSetup holds the launch point, environment and simulation options shared by the projectile commands.
+type simulationSetup struct {
+	launchPoint    geometry.HomogeneousTuple
+	env            projectile.Environment
+	options        projectile.SimulationOptions
+	integratorName string
+}
+
+// parseSimulationFlags reads and validates the persistent flags of projectileCmd.
+func parseSimulationFlags(cmd *cobra.Command) (simulationSetup, error) {
+	launchPointStr, _ := cmd.Flags().GetString("launch-point")
+	windStr, _ := cmd.Flags().GetString("wind")
+	gravity, _ := cmd.Flags().GetFloat64("gravity")
+	maxTicks, _ := cmd.Flags().GetInt("max-ticks")
+	integratorName, _ := cmd.Flags().GetString("integrator")
+	timeStep, _ := cmd.Flags().GetFloat64("dt")
+	mass, _ := cmd.Flags().GetFloat64("mass")
+	dragCoefficient, _ := cmd.Flags().GetFloat64("drag-coefficient")
+	area, _ := cmd.Flags().GetFloat64("area")
+	airDensity, _ := cmd.Flags().GetFloat64("air-density")
+	obstaclesPath, _ := cmd.Flags().GetString("obstacles")
+
+	launchPoint, err := parseTuple(launchPointStr, parseTupleOptions{dimensions: 3, kind: "Point"})
+	if err != nil {
+		return simulationSetup{}, fmt.Errorf("invalid launch point: %v", err)
+	}
+
+	wind, err := parseTuple(windStr, parseTupleOptions{dimensions: 3, kind: "Vector"})
+	if err != nil {
+		// a horizontal wind may be given in two dimensions
+		wind, err = parseTuple(windStr, parseTupleOptions{dimensions: 2, kind: "Vector"})
+		if err != nil {
+			return simulationSetup{}, fmt.Errorf("invalid wind: %v", err)
+		}
+	}
+
+	integrator, err := projectile.IntegratorByName(integratorName)
+	if err != nil {
+		return simulationSetup{}, fmt.Errorf("invalid integrator: %v", err)
+	}
+
+	if !(timeStep > 0) {
+		return simulationSetup{}, fmt.Errorf("invalid time step: %v must be positive", timeStep)
+	}
+
+	if !(mass > 0) {
+		return simulationSetup{}, fmt.Errorf("invalid mass: %v must be positive", mass)
+	}
+
+	if dragCoefficient < 0 || area < 0 || airDensity < 0 {
+		return simulationSetup{}, fmt.Errorf("invalid drag model: coefficient, area and air density must not be negative")
+	}
+
+	var obstacles []projectile.Obstacle
+	if obstaclesPath != "" {
+		file, err := os.Open(obstaclesPath)
+		if err != nil {
+			return simulationSetup{}, fmt.Errorf("cannot read obstacles: %v", err)
+		}
+		defer file.Close()
+		if obstacles, err = parseObstacles(file); err != nil {
+			return simulationSetup{}, fmt.Errorf("invalid obstacles in %s: %v", obstaclesPath, err)
+		}
+	}
+
+	return simulationSetup{
+		launchPoint: launchPoint,
+		env: projectile.Environment{
+			Gravity:    geometry.NewVector(0, 0, -gravity),
+			Wind:       wind,
+			AirDensity: airDensity,
+			Body:       projectile.Body{Mass: mass, DragCoefficient: dragCoefficient, Area: area},
+		},
+		options: projectile.SimulationOptions{
+			Integrator: integrator,
+			TimeStep:   timeStep,
+			MaxTicks:   maxTicks,
+			Obstacles:  obstacles,
+		},
+		integratorName: integratorName,
+	}, nil
+}
+
+// writeOutput calls write with the file at outputPath, or with stdout when no path is given.
+func writeOutput(stdout io.Writer, outputPath string, write func(w io.Writer) error) error {
+	if outputPath == "" {
+		return write(stdout)
+	}
+
+	file, err := os.Create(outputPath)
+	if err != nil {
+		return fmt.Errorf("cannot create output file: %v", err)
+	}
+	if err := write(file); err != nil {
+		file.Close()
+		return err
+	}
+	return file.Close()
+}
+
+// printReport writes the human-readable report: the launch parameters, the per-tick table and the summary.
+// The vacuum solution lands on the default ground, so it is only compared when no other ground or obstacle ended the flight.
+func printReport(w io.Writer, params projectile.LaunchParameters, env projectile.Environment, obstacles []projectile.Obstacle, trajectory projectile.Trajectory) error {
+	launch := trajectory.Samples[0].Projectile
+
+	fmt.Fprintf(w, "Projectile Simulation:\n")
+	fmt.Fprintf(w, "\tLaunch Point: %s\n", launch.Position.String())
+	fmt.Fprintf(w, "\tVelocity    : %s\n", launch.Velocity.String())
+	fmt.Fprintf(w, "\tWind        : %s\n", env.Wind.String())
+	fmt.Fprintf(w, "\tGravity     : %s\n", env.Gravity.String())
+	if env.HasDrag() {
+		fmt.Fprintf(w, "\tDrag        : mass=%g, Cd=%g, area=%g, air density=%g\n", params.Mass, params.DragCoefficient, params.Area, params.AirDensity)
+	}
+	fmt.Fprintf(w, "\tIntegrator  : %s (dt=%g)\n", params.Integrator, params.TimeStep)
+
+	fmt.Fprintln(w)
+	printTrajectory(w, trajectory)
+	fmt.Fprintln(w)
+	printSummary(w, trajectory.Summarize())
+	customGround := slices.ContainsFunc(obstacles, func(o projectile.Obstacle) bool { return o.Ground })
+	if env.Wind.Magnitude() == 0 && !env.HasDrag() && trajectory.Bounces == 0 && !customGround && trajectory.Stop != projectile.StopHitObstacle {
+		printVacuumComparison(w, env.Gravity, trajectory)
+	}
+
+	return nil
+}
+
+// printTrajectory writes one row per tick with the position and velocity of the projectile.
+func printTrajectory(w io.Writer, trajectory projectile.Trajectory) {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', tabwriter.AlignRight)
+	fmt.Fprintln(tw, "Tick\tTime\tX\tY\tZ\tVX\tVY\tVZ\t")
+	for _, s := range trajectory.Samples {
+		fmt.Fprintf(tw, "%d\t%.3f\t%.3f\t%.3f\t%.3f\t%.3f\t%.3f\t%.3f\t\n",
+			s.Tick, s.Time,
+			s.Position.X(), s.Position.Y(), s.Position.Z(),
+			s.Velocity.X(), s.Velocity.Y(), s.Velocity.Z())
+	}
+	tw.Flush()
+}
+
+// printSummary writes the flight time, apex, final position and stop reason of the simulated flight.
+func printSummary(w io.Writer, summary projectile.Summary) {
+	fmt.Fprintf(w, "Summary:\n")
+	fmt.Fprintf(w, "\tFlight Time : %g (%d ticks)\n", summary.FlightTime, summary.Ticks)
+	fmt.Fprintf(w, "\tApex Height : %f\n", summary.Apex.Z())
+	fmt.Fprintf(w, "\tFinal Point : %s\n", summary.Landing.String())
+	if summary.Obstacle != "" && !summary.Landed {
+		fmt.Fprintf(w, "\tStopped     : %s (%s)\n", summary.Stop, summary.Obstacle)
+	} else {
+		fmt.Fprintf(w, "\tStopped     : %s\n", summary.Stop)
+	}
+	if summary.Bounces > 0 {
+		fmt.Fprintf(w, "\tBounces     : %d\n", summary.Bounces)
+	}
+}
+
 func init() {
 	rootCmd.AddCommand(projectileCmd)
 
-	projectileCmd.Flags().StringP("launch-point", "l", "", "Point from which the projectile is launched (x,y,z)")
+	projectileCmd.PersistentFlags().StringP("launch-point", "l", "", "Point from which the projectile is launched (x,y,z)")
+	projectileCmd.PersistentFlags().StringP("wind", "w", "", "Wind vector (x,y[,z]); an acceleration, or the air velocity when drag is modeled")
+	projectileCmd.PersistentFlags().Float64P("gravity", "g", 9.81, "Gravity constant")
+	projectileCmd.PersistentFlags().Float64("mass", 1, "Mass of the projectile (kg)")
+	projectileCmd.PersistentFlags().Float64("drag-coefficient", 0, "Drag coefficient of the projectile (0 disables drag)")
+	projectileCmd.PersistentFlags().Float64("area", 0, "Cross-section area of the projectile (m^2)")
+	projectileCmd.PersistentFlags().Float64("air-density", 1.225, "Density of the air (kg/m^3)")
+	projectileCmd.PersistentFlags().String("integrator", projectile.DefaultIntegrator, "Numerical integrator: "+strings.Join(projectile.IntegratorNames(), ", "))
+	projectileCmd.PersistentFlags().Float64("dt", projectile.DefaultTimeStep, "Time step of each tick")
+	projectileCmd.PersistentFlags().Int("max-ticks", projectile.DefaultMaxTicks, "Maximum number of ticks to simulate")
+	projectileCmd.PersistentFlags().String("obstacles", "", "File describing the ground and obstacles (ground, plane and box lines)")
+
 	projectileCmd.Flags().StringP("velocity", "v", "", "Launch velocity vector of the projectile (x,y,z)")
-	projectileCmd.Flags().StringP("wind", "w", "", "Wind vector (x,y)")
-	projectileCmd.Flags().Float64P("gravity", "g", 9.81, "Gravity constant")
+	projectileCmd.Flags().String("format", "text", "Output format: text, "+strings.Join(projectile.ExportFormats, ", "))
+	projectileCmd.Flags().StringP("output", "o", "", "File to write the output to (default: stdout)")
+	projectileCmd.Flags().String("plot", "", "Image file to plot the x/z trajectory to")
+	projectileCmd.Flags().Int("plot-width", 900, "Width of the plot in pixels")
+	projectileCmd.Flags().Int("plot-height", 550, "Height of the plot in pixels")
+	projectileCmd.Flags().String("plot-format", "", "Image format of the plot: p3 (plain PPM), p6 (binary PPM) or png (default inferred from the file extension)")
+}
+
+// printVacuumComparison writes the closed-form vacuum solution next to the simulated one.
+// It is only meaningful when gravity is the sole force acting on the projectile.
+func printVacuumComparison(w io.Writer, gravity geometry.HomogeneousTuple, trajectory projectile.Trajectory) {
+	launch := trajectory.Samples[0].Projectile
+	flightTime := projectile.VacuumFlightTime(gravity, launch)
+
+	fmt.Fprintf(w, "\nVacuum Solution:\n")
+	fmt.Fprintf(w, "\tFlight Time : %f\n", flightTime)
+	if launch.Velocity.Z() > 0 && gravity.Z() < 0 {
+		apexTime := -launch.Velocity.Z() / gravity.Z()
+		fmt.Fprintf(w, "\tApex Height : %f\n", projectile.VacuumPosition(gravity, launch, apexTime).Z())
+	} else {
+		fmt.Fprintf(w, "\tApex Height : %f\n", launch.Position.Z())
+	}
+	if !math.IsNaN(flightTime) {
+		fmt.Fprintf(w, "\tLanding     : %s\n", projectile.VacuumPosition(gravity, launch, flightTime).String())
+	}
+	fmt.Fprintf(w, "\tMax Error   : %g\n", projectile.VacuumError(gravity, trajectory))
 }
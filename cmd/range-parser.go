@@ -0,0 +1,46 @@
+package cmd
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// parseRange parses a range of values in the format "start:end:step", or a single value.
+// The range includes both ends when end is reached by a whole number of steps.
+// If the input is invalid, it returns nil and an error.
+func parseRange(input string) ([]float64, error) {
+	parts := strings.Split(strings.TrimSpace(input), ":")
+	if len(parts) != 1 && len(parts) != 3 {
+		return nil, fmt.Errorf("invalid input: expected format 'start:end:step' or a single value")
+	}
+
+	values := make([]float64, len(parts))
+	for i, part := range parts {
+		v, err := strconv.ParseFloat(strings.TrimSpace(part), 64)
+		if err != nil || math.IsNaN(v) || math.IsInf(v, 0) {
+			return nil, fmt.Errorf("invalid input: %q is not a number", part)
+		}
+		values[i] = v
+	}
+	if len(values) == 1 {
+		return values, nil
+	}
+
+	start, end, step := values[0], values[1], values[2]
+	if !(step > 0) {
+		return nil, fmt.Errorf("invalid input: step must be positive")
+	}
+	if end < start {
+		return nil, fmt.Errorf("invalid input: end must not be less than start")
+	}
+
+	// allow for rounding so that e.g. 0:1:0.1 includes 1
+	count := int(math.Floor((end-start)/step+1e-9)) + 1
+	result := make([]float64, count)
+	for i := range result {
+		result[i] = start + float64(i)*step
+	}
+	return result, nil
+}
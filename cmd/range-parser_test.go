@@ -0,0 +1,42 @@
+package cmd
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestParseRange(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected []float64
+		wantErr  bool
+	}{
+		{name: "single value", input: "45", expected: []float64{45}},
+		{name: "inclusive range", input: "10:30:10", expected: []float64{10, 20, 30}},
+		{name: "end not on a step", input: "0:25:10", expected: []float64{0, 10, 20}},
+		{name: "fractional step reaches the end", input: "0:0.3:0.1", expected: []float64{0, 0.1, 0.2, 0.30000000000000004}},
+		{name: "missing step", input: "0:10", wantErr: true},
+		{name: "zero step", input: "0:10:0", wantErr: true},
+		{name: "reversed range", input: "10:0:1", wantErr: true},
+		{name: "not a number", input: "a:b:c", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseRange(tt.input)
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("expected an error, got %v", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+			if !slices.Equal(got, tt.expected) {
+				t.Errorf("expected %v, got %v", tt.expected, got)
+			}
+		})
+	}
+}
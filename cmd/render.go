@@ -0,0 +1,178 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/seanpk/go-for-rays/internal/canvas"
+	"github.com/seanpk/go-for-rays/internal/geometry"
+	"github.com/seanpk/go-for-rays/internal/render"
+	"github.com/seanpk/go-for-rays/internal/shading"
+	"github.com/seanpk/go-for-rays/internal/shapes"
+	"github.com/spf13/cobra"
+)
+
+// renderCmd represents the render command
+var renderCmd = &cobra.Command{
+	Use:   "render",
+	Short: "Render a scene to an image",
+	Long: `This command ray traces a scene and writes the image as a PPM or PNG file.
+The camera sits at the from point looking at the to point; the field of view is in degrees.
+The scene is read from a YAML or JSON file (see --scene and the scene command); without one, it renders a demo scene of three spheres in a room.
+The camera flags override the camera of the scene file when they are given.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		scenePath, _ := cmd.Flags().GetString("scene")
+		outputPath, _ := cmd.Flags().GetString("output")
+		format, _ := cmd.Flags().GetString("format")
+		epsilon, _ := cmd.Flags().GetFloat64("epsilon")
+		maxDepth, _ := cmd.Flags().GetInt("max-depth")
+		transparentShadows, _ := cmd.Flags().GetBool("transparent-shadows")
+
+		if !(epsilon > 0) {
+			return fmt.Errorf("invalid epsilon: %v must be positive", epsilon)
+		}
+		if maxDepth < 0 {
+			return fmt.Errorf("invalid max depth: %d must not be negative", maxDepth)
+		}
+
+		if format == "" {
+			format = canvas.FormatForPath(outputPath)
+		}
+		if !slices.Contains(canvas.Formats, format) {
+			return fmt.Errorf("invalid format: %q is not one of %s", format, strings.Join(canvas.Formats, ", "))
+		}
+
+		world := demoWorld()
+		var settings cameraSettings
+		sceneCamera := false
+		if scenePath != "" {
+			file, err := os.Open(scenePath)
+			if err != nil {
+				return fmt.Errorf("cannot read scene: %v", err)
+			}
+			defer file.Close()
+			s, err := parseScene(file, sceneFormatForPath(scenePath))
+			if err != nil {
+				return fmt.Errorf("invalid scene in %s:\n%v", scenePath, err)
+			}
+			world = s.world
+			if s.camera != nil {
+				settings, sceneCamera = *s.camera, true
+			}
+		}
+
+		// the camera flags override the camera of the scene only when they are given explicitly
+		if err := parseCameraFlags(cmd, &settings, sceneCamera); err != nil {
+			return err
+		}
+		camera, err := settings.newCamera()
+		if err != nil {
+			return err
+		}
+
+		image := camera.Render(world, render.RenderOptions{Epsilon: epsilon, MaxDepth: &maxDepth, TransparentShadows: transparentShadows})
+		return writeOutput(cmd.OutOrStdout(), outputPath, func(w io.Writer) error {
+			return image.Write(w, format)
+		})
+	},
+}
+
+// parseCameraFlags reads the camera flags of renderCmd into the settings.
+// With onlyChanged, flags left at their default values do not change the settings.
+func parseCameraFlags(cmd *cobra.Command, settings *cameraSettings, onlyChanged bool) error {
+	use := func(name string) bool {
+		return !onlyChanged || cmd.Flags().Changed(name)
+	}
+
+	if use("width") {
+		settings.width, _ = cmd.Flags().GetInt("width")
+	}
+	if use("height") {
+		settings.height, _ = cmd.Flags().GetInt("height")
+	}
+	if use("fov") {
+		fieldOfView, _ := cmd.Flags().GetFloat64("fov")
+		settings.fieldOfView = fieldOfView * math.Pi / 180
+	}
+
+	tuples := []struct {
+		name  string
+		kind  string
+		tuple *geometry.HomogeneousTuple
+	}{
+		{name: "from", kind: "Point", tuple: &settings.from},
+		{name: "to", kind: "Point", tuple: &settings.to},
+		{name: "up", kind: "Vector", tuple: &settings.up},
+	}
+	for _, t := range tuples {
+		if !use(t.name) {
+			continue
+		}
+		value, _ := cmd.Flags().GetString(t.name)
+		tuple, err := parseTuple(value, parseTupleOptions{dimensions: 3, kind: t.kind})
+		if err != nil {
+			return fmt.Errorf("invalid %s: %v", t.name, err)
+		}
+		*t.tuple = tuple
+	}
+	return nil
+}
+
+// demoWorld builds the scene at the end of the book's chapter on cameras: three spheres in a room
+// whose floor and walls are flattened spheres.
+func demoWorld() *render.World {
+	wallMaterial := shading.DefaultMaterial()
+	wallMaterial.Color = canvas.NewColor(1, 0.9, 0.9)
+	wallMaterial.Specular = 0
+
+	wall := func(transform geometry.Matrix4) shapes.Shape {
+		s := shapes.NewSphere()
+		s.SetTransform(transform)
+		s.SetMaterial(wallMaterial)
+		return s
+	}
+	flat := geometry.Scaling(10, 0.01, 10)
+	floor := wall(flat)
+	leftWall := wall(flat.RotateX(math.Pi/2).RotateY(-math.Pi/4).Translate(0, 0, 5))
+	rightWall := wall(flat.RotateX(math.Pi/2).RotateY(math.Pi/4).Translate(0, 0, 5))
+
+	ball := func(transform geometry.Matrix4, color canvas.Color) shapes.Shape {
+		s := shapes.NewSphere()
+		s.SetTransform(transform)
+		m := shading.DefaultMaterial()
+		m.Color = color
+		m.Diffuse = 0.7
+		m.Specular = 0.3
+		s.SetMaterial(m)
+		return s
+	}
+	middle := ball(geometry.Translation(-0.5, 1, 0.5), canvas.NewColor(0.1, 1, 0.5))
+	right := ball(geometry.Scaling(0.5, 0.5, 0.5).Translate(1.5, 0.5, -0.5), canvas.NewColor(0.5, 1, 0.1))
+	left := ball(geometry.Scaling(0.33, 0.33, 0.33).Translate(-1.5, 0.33, -0.75), canvas.NewColor(1, 0.8, 0.1))
+
+	w := render.NewWorld()
+	w.Objects = []shapes.Shape{floor, leftWall, rightWall, middle, right, left}
+	w.Lights = []shading.PointLight{shading.NewPointLight(geometry.NewPoint(-10, 10, -10), canvas.White)}
+	return w
+}
+
+func init() {
+	rootCmd.AddCommand(renderCmd)
+
+	renderCmd.Flags().String("scene", "", "Scene file (YAML, or JSON if it ends in .json) describing the camera, lights, shapes and materials")
+	renderCmd.Flags().Int("width", 400, "Width of the image in pixels")
+	renderCmd.Flags().Int("height", 200, "Height of the image in pixels")
+	renderCmd.Flags().Float64("fov", 60, "Field of view of the camera, in degrees")
+	renderCmd.Flags().String("from", "(0,1.5,-5)", "Position of the camera (x,y,z)")
+	renderCmd.Flags().String("to", "(0,1,0)", "Point the camera looks at (x,y,z)")
+	renderCmd.Flags().String("up", "(0,1,0)", "Direction that is up for the camera (x,y,z)")
+	renderCmd.Flags().Float64("epsilon", geometry.EPSILON, "Offset of shadow rays from surfaces; lower it for scenes at a tiny scale")
+	renderCmd.Flags().Int("max-depth", render.DefaultMaxDepth, "Maximum number of times a ray is reflected or refracted before it stops contributing color (0 disables reflection and refraction)")
+	renderCmd.Flags().Bool("transparent-shadows", false, "Let light pass through transparent objects instead of casting shadows")
+	renderCmd.Flags().StringP("output", "o", "", "Image file to write (default: stdout)")
+	renderCmd.Flags().String("format", "", "Image format: p3 (plain PPM), p6 (binary PPM) or png (default inferred from the file extension)")
+}
@@ -0,0 +1,843 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"math"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/seanpk/go-for-rays/internal/canvas"
+	"github.com/seanpk/go-for-rays/internal/geometry"
+	"github.com/seanpk/go-for-rays/internal/render"
+	"github.com/seanpk/go-for-rays/internal/shading"
+	"github.com/seanpk/go-for-rays/internal/shapes"
+	"gopkg.in/yaml.v3"
+)
+
+// scene is a world loaded from a scene file, along with the camera the file describes, if any.
+type scene struct {
+	camera *cameraSettings
+	world  *render.World
+}
+
+// cameraSettings describes a camera before it is built.
+type cameraSettings struct {
+	width       int
+	height      int
+	fieldOfView float64 // in radians
+	from        geometry.HomogeneousTuple
+	to          geometry.HomogeneousTuple
+	up          geometry.HomogeneousTuple
+}
+
+// newCamera builds the camera, oriented with a view transform.
+func (s cameraSettings) newCamera() (*render.Camera, error) {
+	camera, err := render.NewCamera(s.width, s.height, s.fieldOfView)
+	if err != nil {
+		return nil, err
+	}
+	if err := camera.SetTransform(geometry.ViewTransform(s.from, s.to, s.up)); err != nil {
+		return nil, fmt.Errorf("invalid camera orientation: %v", err)
+	}
+	return camera, nil
+}
+
+// sceneError is a problem in a scene file, located at the line and column of the offending value,
+// and by the JSON Pointer (RFC 6901) to that value within the document.
+type sceneError struct {
+	line    int
+	column  int
+	pointer string
+	message string
+}
+
+func (e sceneError) Error() string {
+	return fmt.Sprintf("line %d, column %d: %s", e.line, e.column, e.message)
+}
+
+// sceneErrors holds every problem found in a scene file, in the order they occur.
+type sceneErrors []sceneError
+
+func (errs sceneErrors) Error() string {
+	messages := make([]string, len(errs))
+	for i, err := range errs {
+		messages[i] = err.Error()
+	}
+	return strings.Join(messages, "\n")
+}
+
+// sceneShapes maps the shape names of scene files to their constructors.
+var sceneShapes = map[string]func() shapes.Shape{
+	"sphere": func() shapes.Shape { return shapes.NewSphere() },
+	"plane":  func() shapes.Shape { return shapes.NewPlane() },
+	"cube":   func() shapes.Shape { return shapes.NewCube() },
+}
+
+// transformArguments gives the number of arguments of each transform of scene files.
+var transformArguments = map[string]int{
+	"translate": 3,
+	"scale":     3,
+	"rotate-x":  1,
+	"rotate-y":  1,
+	"rotate-z":  1,
+	"shear":     6,
+}
+
+// parseScene reads a YAML scene in the format of the book: a list of items, each of which either
+// adds something to the scene or defines a reusable material or transform.
+//
+//	# scene.yaml
+//	- add: camera
+//	  width: 100
+//	  height: 50
+//	  field-of-view: 1.0472        # radians
+//	  from: [0, 1.5, -5]           # tuples are [x, y, z] or "(x,y,z)"
+//	  to: [0, 1, 0]
+//	  up: [0, 1, 0]
+//	- add: light
+//	  at: [-10, 10, -10]
+//	  intensity: [1, 1, 1]
+//	- define: shiny
+//	  value: { color: [1, 0.2, 1], specular: 0.9 }
+//	- define: shiny-blue
+//	  extend: shiny                # a definition may extend an earlier one
+//	  value: { color: [0.2, 0.2, 1] }
+//	- add: sphere
+//	  material: shiny-blue         # a definition name or a material
+//	  transform:                   # applied in order
+//	    - [scale, 0.5, 0.5, 0.5]
+//	    - [translate, 0, 1, 0]
+//
+// The same scene can be written in JSON, which is read with the same rules; see sceneSchema.
+// Every problem in the file is reported, with the line and column it occurs at, as sceneErrors.
+func parseScene(r io.Reader, format string) (scene, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return scene{}, err
+	}
+
+	switch format {
+	case "yaml":
+	case "json":
+		// JSON is valid YAML, so once it is known to be strict JSON, it is read as YAML to locate values
+		var syntax *json.SyntaxError
+		if err := json.Unmarshal(data, new(any)); errors.As(err, &syntax) {
+			line, column := textPosition(data, syntax.Offset-1) // the offset is just after the offending byte
+			return scene{}, fmt.Errorf("invalid JSON: line %d, column %d: %v", line, column, err)
+		} else if err != nil {
+			return scene{}, fmt.Errorf("invalid JSON: %v", err)
+		}
+	default:
+		return scene{}, fmt.Errorf("unknown scene format %q: expected one of %s", format, strings.Join(sceneFormats, ", "))
+	}
+
+	var document yaml.Node
+	if err := yaml.Unmarshal(data, &document); err != nil {
+		return scene{}, fmt.Errorf("invalid YAML: %v", err)
+	}
+	if len(document.Content) == 0 {
+		return scene{}, fmt.Errorf("empty scene")
+	}
+
+	root := document.Content[0]
+	l := &sceneLoader{
+		pointers:    nodePointers(root),
+		definitions: map[string]*sceneDefinition{},
+		world:       render.NewWorld(),
+	}
+	l.load(root)
+	if len(l.errs) > 0 {
+		return scene{}, l.errs
+	}
+	return scene{camera: l.camera, world: l.world}, nil
+}
+
+// sceneFormats lists the formats of scene files.
+var sceneFormats = []string{"yaml", "json"}
+
+// sceneFormatForPath picks the scene format from the file extension: json for .json, and yaml for anything else.
+func sceneFormatForPath(path string) string {
+	if strings.EqualFold(filepath.Ext(path), ".json") {
+		return "json"
+	}
+	return "yaml"
+}
+
+// textPosition converts a byte offset into a line and column, both starting at 1.
+func textPosition(data []byte, offset int64) (line, column int) {
+	offset = min(max(offset, 0), int64(len(data)))
+	before := data[:offset]
+	line = bytes.Count(before, []byte("\n")) + 1
+	column = len(before) - bytes.LastIndexByte(before, '\n')
+	return line, column
+}
+
+// sceneLoader builds a scene from the YAML nodes of a scene file, collecting errors as it goes.
+type sceneLoader struct {
+	pointers    map[*yaml.Node]string
+	definitions map[string]*sceneDefinition
+	camera      *cameraSettings
+	world       *render.World
+	errs        sceneErrors
+}
+
+func (l *sceneLoader) errorf(node *yaml.Node, format string, args ...any) {
+	l.errs = append(l.errs, sceneError{
+		line:    node.Line,
+		column:  node.Column,
+		pointer: l.pointers[node],
+		message: fmt.Sprintf(format, args...),
+	})
+}
+
+func (l *sceneLoader) load(root *yaml.Node) {
+	root = resolveAlias(root)
+	if root.Kind != yaml.SequenceNode {
+		l.errorf(root, "a scene must be a list of items")
+		return
+	}
+
+	for _, item := range root.Content {
+		item = resolveAlias(item)
+		if item.Kind != yaml.MappingNode {
+			l.errorf(item, "an item must be a mapping with an add or define key")
+			continue
+		}
+		fields := mappingFields(item)
+		if _, ok := fields["define"]; ok {
+			l.loadDefinition(item)
+			continue
+		}
+		add, ok := fields["add"]
+		if !ok {
+			l.errorf(item, "an item must have an add or define key")
+			continue
+		}
+		switch kind := add.value.Value; {
+		case kind == "camera":
+			l.loadCamera(item)
+		case kind == "light":
+			l.loadLight(item)
+		case sceneShapes[kind] != nil:
+			l.loadShape(item, sceneShapes[kind]())
+		default:
+			l.errorf(add.value, "unknown item %q: expected camera, light or one of %s", kind, strings.Join(slices.Sorted(maps.Keys(sceneShapes)), ", "))
+		}
+	}
+}
+
+func (l *sceneLoader) loadDefinition(item *yaml.Node) {
+	fields, ok := l.fields(item, []string{"define", "value"}, "extend")
+	if !ok {
+		return
+	}
+	name := fields["define"]
+	if name.Kind != yaml.ScalarNode || name.Tag != "!!str" || name.Value == "" {
+		l.errorf(name, "a definition name must be a string")
+		return
+	}
+	if _, exists := l.definitions[name.Value]; exists {
+		l.errorf(name, "%q is already defined", name.Value)
+		return
+	}
+
+	value := fields["value"]
+	if value.Kind != yaml.MappingNode && value.Kind != yaml.SequenceNode {
+		l.errorf(value, "a definition must be a material (mapping) or a transform (list)")
+		return
+	}
+	if base, ok := fields["extend"]; ok {
+		parent, ok := l.definition(base)
+		if !ok {
+			return
+		}
+		if parent.node.Kind != value.Kind {
+			l.errorf(base, "cannot extend %q: it is a %s, not a %s", base.Value, parent.kind(), definitionKind(value))
+			return
+		}
+		// the values of the new definition come after, and so override, the values of the parent
+		extended := *value
+		extended.Content = append(slices.Clone(parent.node.Content), value.Content...)
+		value = &extended
+		if !parent.valid {
+			// the errors of the parent are already reported
+			l.definitions[name.Value] = &sceneDefinition{node: value}
+			return
+		}
+	}
+
+	definition := &sceneDefinition{node: value}
+	if value.Kind == yaml.MappingNode {
+		definition.material, definition.valid = l.material(value)
+	} else {
+		definition.transform, definition.valid = l.transform(value)
+	}
+	l.definitions[name.Value] = definition
+}
+
+// sceneDefinition is a named material or transform, read when it is defined.
+type sceneDefinition struct {
+	node      *yaml.Node
+	material  shading.Material
+	transform geometry.Matrix4
+	valid     bool // false if the definition has errors, which were reported where it is defined
+}
+
+func (d *sceneDefinition) kind() string {
+	return definitionKind(d.node)
+}
+
+func definitionKind(node *yaml.Node) string {
+	if node.Kind == yaml.MappingNode {
+		return "material"
+	}
+	return "transform"
+}
+
+// definition looks up the definition named by the node.
+func (l *sceneLoader) definition(name *yaml.Node) (*sceneDefinition, bool) {
+	definition, ok := l.definitions[name.Value]
+	if !ok {
+		l.errorf(name, "%q is not defined", name.Value)
+	}
+	return definition, ok
+}
+
+func (l *sceneLoader) loadCamera(item *yaml.Node) {
+	fields, ok := l.fields(item, []string{"add", "width", "height", "field-of-view", "from", "to", "up"})
+	if !ok {
+		return
+	}
+	if l.camera != nil {
+		l.errorf(item, "a scene can only have one camera")
+		return
+	}
+
+	width, okWidth := l.size(fields["width"])
+	height, okHeight := l.size(fields["height"])
+	fieldOfView, okFieldOfView := l.number(fields["field-of-view"])
+	if okFieldOfView && !(fieldOfView > 0 && fieldOfView < math.Pi) {
+		l.errorf(fields["field-of-view"], "field-of-view must be between 0 and pi radians")
+		okFieldOfView = false
+	}
+	from, okFrom := l.tuple(fields["from"], "Point")
+	to, okTo := l.tuple(fields["to"], "Point")
+	up, okUp := l.tuple(fields["up"], "Vector")
+	if okWidth && okHeight && okFieldOfView && okFrom && okTo && okUp {
+		l.camera = &cameraSettings{width: width, height: height, fieldOfView: fieldOfView, from: from, to: to, up: up}
+	}
+}
+
+func (l *sceneLoader) loadLight(item *yaml.Node) {
+	fields, ok := l.fields(item, []string{"add", "at", "intensity"})
+	if !ok {
+		return
+	}
+	position, okPosition := l.tuple(fields["at"], "Point")
+	intensity, okIntensity := l.color(fields["intensity"])
+	if okPosition && okIntensity {
+		l.world.Lights = append(l.world.Lights, shading.NewPointLight(position, intensity))
+	}
+}
+
+func (l *sceneLoader) loadShape(item *yaml.Node, shape shapes.Shape) {
+	fields, ok := l.fields(item, []string{"add"}, "material", "transform")
+	if !ok {
+		return
+	}
+	if node, ok := fields["material"]; ok {
+		if material, ok := l.material(node); ok {
+			shape.SetMaterial(material)
+		}
+	}
+	if node, ok := fields["transform"]; ok {
+		if transform, ok := l.transform(node); ok {
+			if err := shape.SetTransform(transform); err != nil {
+				l.errorf(node, "invalid transform: %v", err)
+			}
+		}
+	}
+	l.world.Objects = append(l.world.Objects, shape)
+}
+
+// material reads a material, given as a mapping or as the name of a material definition.
+// Unspecified properties keep the values of shading.DefaultMaterial.
+func (l *sceneLoader) material(node *yaml.Node) (shading.Material, bool) {
+	node = resolveAlias(node)
+	if node.Kind == yaml.ScalarNode {
+		definition, ok := l.definition(node)
+		if !ok {
+			return shading.Material{}, false
+		}
+		if definition.node.Kind != yaml.MappingNode {
+			l.errorf(node, "%q is a transform, not a material", node.Value)
+			return shading.Material{}, false
+		}
+		return definition.material, definition.valid
+	}
+	if node.Kind != yaml.MappingNode {
+		l.errorf(node, "a material must be a mapping or the name of a definition")
+		return shading.Material{}, false
+	}
+
+	material := shading.DefaultMaterial()
+	valid := true
+	for _, field := range mappingPairs(node) {
+		property, known := materialProperties[field.key.Value]
+		if !known {
+			l.errorf(field.key, "unknown material property %q", field.key.Value)
+			valid = false
+			continue
+		}
+		valid = property.read(l, resolveAlias(field.value), &material) && valid
+	}
+	return material, valid
+}
+
+// materialProperty is a property of the materials of scene files: how to read it, and its JSON Schema.
+type materialProperty struct {
+	read   func(l *sceneLoader, node *yaml.Node, m *shading.Material) bool
+	schema map[string]any
+}
+
+// materialProperties maps the property names of scene materials to the fields of shading.Material.
+var materialProperties = map[string]materialProperty{
+	"color": {
+		read: func(l *sceneLoader, node *yaml.Node, m *shading.Material) (ok bool) {
+			m.Color, ok = l.color(node)
+			return ok
+		},
+		schema: schemaRef("color"),
+	},
+	"ambient": {
+		read: func(l *sceneLoader, node *yaml.Node, m *shading.Material) (ok bool) {
+			m.Ambient, ok = l.fraction(node)
+			return ok
+		},
+		schema: schemaRef("fraction"),
+	},
+	"diffuse": {
+		read: func(l *sceneLoader, node *yaml.Node, m *shading.Material) (ok bool) {
+			m.Diffuse, ok = l.fraction(node)
+			return ok
+		},
+		schema: schemaRef("fraction"),
+	},
+	"specular": {
+		read: func(l *sceneLoader, node *yaml.Node, m *shading.Material) (ok bool) {
+			m.Specular, ok = l.fraction(node)
+			return ok
+		},
+		schema: schemaRef("fraction"),
+	},
+	"shininess": {
+		read: func(l *sceneLoader, node *yaml.Node, m *shading.Material) (ok bool) {
+			m.Shininess, ok = l.nonNegative(node)
+			return ok
+		},
+		schema: map[string]any{"type": "number", "minimum": 0},
+	},
+	"pattern": {
+		read: func(l *sceneLoader, node *yaml.Node, m *shading.Material) (ok bool) {
+			m.Pattern, ok = l.pattern(node)
+			return ok
+		},
+		schema: schemaRef("pattern"),
+	},
+	"reflective": {
+		read: func(l *sceneLoader, node *yaml.Node, m *shading.Material) (ok bool) {
+			m.Reflective, ok = l.fraction(node)
+			return ok
+		},
+		schema: schemaRef("fraction"),
+	},
+	"transparency": {
+		read: func(l *sceneLoader, node *yaml.Node, m *shading.Material) (ok bool) {
+			m.Transparency, ok = l.fraction(node)
+			return ok
+		},
+		schema: schemaRef("fraction"),
+	},
+	"refractive-index": {
+		read: func(l *sceneLoader, node *yaml.Node, m *shading.Material) (ok bool) {
+			m.RefractiveIndex, ok = l.positive(node)
+			return ok
+		},
+		schema: map[string]any{"type": "number", "exclusiveMinimum": 0},
+	},
+}
+
+// scenePatterns maps the pattern types of scene files to the constructors of patterns made of two parts.
+var scenePatterns = map[string]func(a, b shading.Pattern) shading.Pattern{
+	"stripes":         func(a, b shading.Pattern) shading.Pattern { return shading.NewStripePattern(a, b) },
+	"gradient":        func(a, b shading.Pattern) shading.Pattern { return shading.NewGradientPattern(a, b) },
+	"rings":           func(a, b shading.Pattern) shading.Pattern { return shading.NewRingPattern(a, b) },
+	"checkers":        func(a, b shading.Pattern) shading.Pattern { return shading.NewCheckerPattern(a, b) },
+	"radial-gradient": func(a, b shading.Pattern) shading.Pattern { return shading.NewRadialGradientPattern(a, b) },
+	"blend":           func(a, b shading.Pattern) shading.Pattern { return shading.NewBlendedPattern(a, b) },
+}
+
+// defaultPerturbScale is how far a perturb pattern jitters points when the scene does not say.
+const defaultPerturbScale = 0.2
+
+// pattern reads a pattern, which is a mapping with a type and, depending on the type, either
+//
+//	colors: [a, b]       # each a color, or a nested pattern
+//
+// or, for the perturb type,
+//
+//	pattern: {...}       # the pattern to jitter
+//	scale: 0.2           # how far points move (optional)
+//
+// along with an optional transform from pattern space to object space.
+func (l *sceneLoader) pattern(node *yaml.Node) (shading.Pattern, bool) {
+	node = resolveAlias(node)
+	if node.Kind != yaml.MappingNode {
+		l.errorf(node, "a pattern must be a mapping with a type")
+		return nil, false
+	}
+	kind, ok := mappingFields(node)["type"]
+	if !ok {
+		l.errorf(node, "missing key %q", "type")
+		return nil, false
+	}
+
+	var pattern shading.Pattern
+	var fields map[string]*yaml.Node
+	valid := true
+	switch newPattern := scenePatterns[kind.value.Value]; {
+	case newPattern != nil:
+		if fields, ok = l.fields(node, []string{"type", "colors"}, "transform"); !ok {
+			return nil, false
+		}
+		colors := fields["colors"]
+		if colors.Kind != yaml.SequenceNode || len(colors.Content) != 2 {
+			l.errorf(colors, "a %s pattern needs a list of two colors or patterns", kind.value.Value)
+			return nil, false
+		}
+		a, okA := l.patternPart(colors.Content[0])
+		b, okB := l.patternPart(colors.Content[1])
+		if !okA || !okB {
+			return nil, false
+		}
+		pattern = newPattern(a, b)
+	case kind.value.Value == "perturb":
+		if fields, ok = l.fields(node, []string{"type", "pattern"}, "scale", "transform"); !ok {
+			return nil, false
+		}
+		inner, ok := l.pattern(fields["pattern"])
+		scale := defaultPerturbScale
+		if node, given := fields["scale"]; given {
+			var okScale bool
+			scale, okScale = l.nonNegative(node)
+			ok = ok && okScale
+		}
+		if !ok {
+			return nil, false
+		}
+		pattern = shading.NewPerturbedPattern(inner, scale)
+	default:
+		types := append(slices.Sorted(maps.Keys(scenePatterns)), "perturb")
+		l.errorf(kind.value, "unknown pattern type %q: expected one of %s", kind.value.Value, strings.Join(types, ", "))
+		return nil, false
+	}
+
+	if node, given := fields["transform"]; given {
+		transform, ok := l.transform(node)
+		if ok {
+			if err := pattern.SetTransform(transform); err != nil {
+				l.errorf(node, "invalid transform: %v", err)
+				ok = false
+			}
+		}
+		valid = valid && ok
+	}
+	return pattern, valid
+}
+
+// patternPart reads a part of a two-part pattern: a nested pattern, or a color for a solid pattern.
+func (l *sceneLoader) patternPart(node *yaml.Node) (shading.Pattern, bool) {
+	node = resolveAlias(node)
+	if node.Kind == yaml.MappingNode {
+		return l.pattern(node)
+	}
+	color, ok := l.color(node)
+	return shading.NewSolidPattern(color), ok
+}
+
+// transform reads a list of transforms, applied in order, each of which is either an operation
+// such as [translate, x, y, z] or the name of a transform definition.
+func (l *sceneLoader) transform(node *yaml.Node) (geometry.Matrix4, bool) {
+	node = resolveAlias(node)
+	if node.Kind == yaml.ScalarNode {
+		definition, ok := l.definition(node)
+		if !ok {
+			return geometry.Matrix4{}, false
+		}
+		if definition.node.Kind != yaml.SequenceNode {
+			l.errorf(node, "%q is a material, not a transform", node.Value)
+			return geometry.Matrix4{}, false
+		}
+		return definition.transform, definition.valid
+	}
+	if node.Kind != yaml.SequenceNode {
+		l.errorf(node, "a transform must be a list of operations or the name of a definition")
+		return geometry.Matrix4{}, false
+	}
+
+	transform := geometry.Identity()
+	valid := true
+	for _, step := range node.Content {
+		step = resolveAlias(step)
+		if step.Kind == yaml.ScalarNode {
+			// a named transform, applied after the steps before it
+			named, ok := l.transform(step)
+			transform, valid = named.Multiply(transform), valid && ok
+			continue
+		}
+		operation, ok := l.operation(step)
+		transform, valid = operation.Multiply(transform), valid && ok
+	}
+	return transform, valid
+}
+
+// operation reads a single transform operation, such as [rotate-y, 1.5708].
+func (l *sceneLoader) operation(node *yaml.Node) (geometry.Matrix4, bool) {
+	if node.Kind != yaml.SequenceNode || len(node.Content) == 0 {
+		l.errorf(node, "a transform operation must be a list such as [translate, x, y, z]")
+		return geometry.Matrix4{}, false
+	}
+	name := node.Content[0]
+	count, known := transformArguments[name.Value]
+	if !known {
+		l.errorf(name, "unknown transform %q: expected one of %s", name.Value, strings.Join(slices.Sorted(maps.Keys(transformArguments)), ", "))
+		return geometry.Matrix4{}, false
+	}
+	if len(node.Content)-1 != count {
+		l.errorf(node, "%s takes %d arguments, got %d", name.Value, count, len(node.Content)-1)
+		return geometry.Matrix4{}, false
+	}
+
+	args := make([]float64, count)
+	valid := true
+	for i, arg := range node.Content[1:] {
+		var ok bool
+		args[i], ok = l.number(arg)
+		valid = valid && ok
+	}
+	if !valid {
+		return geometry.Matrix4{}, false
+	}
+
+	switch name.Value {
+	case "translate":
+		return geometry.Translation(args[0], args[1], args[2]), true
+	case "scale":
+		return geometry.Scaling(args[0], args[1], args[2]), true
+	case "rotate-x":
+		return geometry.RotationX(args[0]), true
+	case "rotate-y":
+		return geometry.RotationY(args[0]), true
+	case "rotate-z":
+		return geometry.RotationZ(args[0]), true
+	default: // "shear"
+		return geometry.Shearing(args[0], args[1], args[2], args[3], args[4], args[5]), true
+	}
+}
+
+// number reads a scalar number, which must be finite. Quoted numbers are strings, and are not read.
+func (l *sceneLoader) number(node *yaml.Node) (float64, bool) {
+	node = resolveAlias(node)
+	if node.Kind == yaml.ScalarNode && (node.Tag == "!!int" || node.Tag == "!!float") {
+		if value, err := strconv.ParseFloat(node.Value, 64); err == nil && !math.IsNaN(value) && !math.IsInf(value, 0) {
+			return value, true
+		}
+	}
+	l.errorf(node, "expected a number, got %s", describeNode(node))
+	return 0, false
+}
+
+// fraction reads a number from 0 to 1.
+func (l *sceneLoader) fraction(node *yaml.Node) (float64, bool) {
+	value, ok := l.number(node)
+	if ok && (value < 0 || value > 1) {
+		l.errorf(node, "expected a number from 0 to 1, got %v", value)
+		return 0, false
+	}
+	return value, ok
+}
+
+// nonNegative reads a number that is zero or more.
+func (l *sceneLoader) nonNegative(node *yaml.Node) (float64, bool) {
+	value, ok := l.number(node)
+	if ok && value < 0 {
+		l.errorf(node, "expected a number that is not negative, got %v", value)
+		return 0, false
+	}
+	return value, ok
+}
+
+// positive reads a number greater than zero.
+func (l *sceneLoader) positive(node *yaml.Node) (float64, bool) {
+	value, ok := l.number(node)
+	if ok && !(value > 0) {
+		l.errorf(node, "expected a positive number, got %v", value)
+		return 0, false
+	}
+	return value, ok
+}
+
+// size reads a positive number of pixels, which may be written with a fraction of zero, such as 100.0.
+func (l *sceneLoader) size(node *yaml.Node) (int, bool) {
+	node = resolveAlias(node)
+	if node.Kind == yaml.ScalarNode && (node.Tag == "!!int" || node.Tag == "!!float") {
+		if value, err := strconv.ParseFloat(node.Value, 64); err == nil && value > 0 && value <= math.MaxInt32 && value == math.Trunc(value) {
+			return int(value), true
+		}
+	}
+	l.errorf(node, "expected a positive whole number, got %s", describeNode(node))
+	return 0, false
+}
+
+// tuple reads a point or vector, written as [x, y, z] or as "(x,y,z)"; either way it goes through parseTuple.
+func (l *sceneLoader) tuple(node *yaml.Node, kind string) (geometry.HomogeneousTuple, bool) {
+	return l.namedTuple(node, kind, strings.ToLower(kind))
+}
+
+// namedTuple reads a tuple like tuple does, and names it in errors as what it stands for.
+func (l *sceneLoader) namedTuple(node *yaml.Node, kind, name string) (geometry.HomogeneousTuple, bool) {
+	node = resolveAlias(node)
+	text := node.Value
+	if node.Kind == yaml.SequenceNode {
+		components := make([]string, len(node.Content))
+		for i, component := range node.Content {
+			value, ok := l.number(component)
+			if !ok {
+				return geometry.NaNTuple(), false
+			}
+			// the text of a YAML number, such as 1., may not be in the format parseTuple reads
+			components[i] = strconv.FormatFloat(value, 'g', -1, 64)
+		}
+		text = "(" + strings.Join(components, ",") + ")"
+	} else if node.Kind != yaml.ScalarNode {
+		l.errorf(node, "expected a tuple [x, y, z], got %s", describeNode(node))
+		return geometry.NaNTuple(), false
+	}
+
+	tuple, err := parseTuple(text, parseTupleOptions{dimensions: 3, kind: kind})
+	if err != nil {
+		l.errorf(node, "invalid %s: %v", name, err)
+		return geometry.NaNTuple(), false
+	}
+	return tuple, true
+}
+
+// color reads a color, written as a tuple [r, g, b].
+func (l *sceneLoader) color(node *yaml.Node) (canvas.Color, bool) {
+	tuple, ok := l.namedTuple(node, "Vector", "color")
+	if !ok {
+		return canvas.Black, false
+	}
+	return canvas.NewColor(tuple.X(), tuple.Y(), tuple.Z()), true
+}
+
+// fields returns the values of a mapping by key, after checking that every required key is present
+// and that there are no keys other than the required and optional ones.
+func (l *sceneLoader) fields(node *yaml.Node, required []string, optional ...string) (map[string]*yaml.Node, bool) {
+	valid := true
+	fields := map[string]*yaml.Node{}
+	for _, field := range mappingPairs(node) {
+		key := field.key.Value
+		switch {
+		case !slices.Contains(required, key) && !slices.Contains(optional, key):
+			l.errorf(field.key, "unknown key %q", key)
+			valid = false
+		case fields[key] != nil:
+			l.errorf(field.key, "duplicate key %q", key)
+			valid = false
+		default:
+			fields[key] = resolveAlias(field.value)
+		}
+	}
+	for _, key := range required {
+		if fields[key] == nil {
+			l.errorf(node, "missing key %q", key)
+			valid = false
+		}
+	}
+	return fields, valid
+}
+
+// nodePair is a key and its value in a YAML mapping.
+type nodePair struct {
+	key   *yaml.Node
+	value *yaml.Node
+}
+
+func mappingPairs(node *yaml.Node) []nodePair {
+	pairs := make([]nodePair, 0, len(node.Content)/2)
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		pairs = append(pairs, nodePair{key: node.Content[i], value: node.Content[i+1]})
+	}
+	return pairs
+}
+
+// mappingFields indexes the pairs of a mapping by key; with duplicate keys, the last one wins.
+func mappingFields(node *yaml.Node) map[string]nodePair {
+	fields := map[string]nodePair{}
+	for _, pair := range mappingPairs(node) {
+		fields[pair.key.Value] = pair
+	}
+	return fields
+}
+
+// nodePointers maps every node under the root to its JSON Pointer; the keys of mappings map to the pointer of their value.
+func nodePointers(root *yaml.Node) map[*yaml.Node]string {
+	pointers := map[*yaml.Node]string{}
+	var walk func(node *yaml.Node, pointer string)
+	walk = func(node *yaml.Node, pointer string) {
+		pointers[node] = pointer
+		switch node.Kind {
+		case yaml.SequenceNode:
+			for i, item := range node.Content {
+				walk(item, pointer+"/"+strconv.Itoa(i))
+			}
+		case yaml.MappingNode:
+			for _, pair := range mappingPairs(node) {
+				child := pointer + "/" + pointerEscaper.Replace(pair.key.Value)
+				pointers[pair.key] = child
+				walk(pair.value, child)
+			}
+		}
+	}
+	walk(root, "")
+	return pointers
+}
+
+// pointerEscaper escapes the reference tokens of JSON Pointers.
+var pointerEscaper = strings.NewReplacer("~", "~0", "/", "~1")
+
+func resolveAlias(node *yaml.Node) *yaml.Node {
+	for node.Kind == yaml.AliasNode {
+		node = node.Alias
+	}
+	return node
+}
+
+func describeNode(node *yaml.Node) string {
+	switch node.Kind {
+	case yaml.MappingNode:
+		return "a mapping"
+	case yaml.SequenceNode:
+		return "a list"
+	default:
+		return strconv.Quote(node.Value)
+	}
+}
@@ -0,0 +1,448 @@
+package cmd
+
+import (
+	"errors"
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/seanpk/go-for-rays/internal/canvas"
+	"github.com/seanpk/go-for-rays/internal/geometry"
+	"github.com/seanpk/go-for-rays/internal/shading"
+	"github.com/seanpk/go-for-rays/internal/shapes"
+)
+
+func TestParseScene(t *testing.T) {
+	input := `
+- add: camera
+  width: 100
+  height: 50
+  field-of-view: 0.785
+  from: [0, 1.5, -5]
+  to: "(0, 1, 0)"
+  up: [0, 1, 0]
+- add: light
+  at: [-10, 10, -10]
+  intensity: [1, 1, 1]
+- define: shiny
+  value:
+    color: [1, 0.2, 1]
+    specular: 0.9
+- define: shiny-blue
+  extend: shiny
+  value:
+    color: [0.2, 0.2, 1]
+- define: lifted
+  value:
+    - [translate, 0, 1, 0]
+- add: sphere
+  material: shiny-blue
+  transform:
+    - [scale, 0.5, 0.5, 0.5]
+    - lifted
+- add: sphere
+  material:
+    diffuse: 0.5
+    reflective: 0.25
+    transparency: 0.75
+    refractive-index: 1.52
+`
+	s, err := parseScene(strings.NewReader(input), "yaml")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if s.camera == nil {
+		t.Fatalf("expected a camera")
+	}
+	c := *s.camera
+	if c.width != 100 || c.height != 50 || c.fieldOfView != 0.785 {
+		t.Errorf("expected a 100x50 camera with a field of view of 0.785, got %+v", c)
+	}
+	if !c.from.Equals(geometry.NewPoint(0, 1.5, -5)) || !c.to.Equals(geometry.NewPoint(0, 1, 0)) || !c.up.Equals(geometry.NewVector(0, 1, 0)) {
+		t.Errorf("expected the camera orientation from the scene, got %v, %v, %v", c.from, c.to, c.up)
+	}
+
+	if len(s.world.Lights) != 1 || !s.world.Lights[0].Position.Equals(geometry.NewPoint(-10, 10, -10)) {
+		t.Errorf("expected one light at (-10,10,-10), got %+v", s.world.Lights)
+	}
+
+	if len(s.world.Objects) != 2 {
+		t.Fatalf("expected 2 objects, got %d", len(s.world.Objects))
+	}
+	first := s.world.Objects[0]
+	if m := first.Material(); !m.Color.Equals(canvas.NewColor(0.2, 0.2, 1)) || m.Specular != 0.9 || m.Diffuse != 0.9 {
+		t.Errorf("expected the extended material, got %+v", m)
+	}
+	if expected := geometry.Scaling(0.5, 0.5, 0.5).Translate(0, 1, 0); !first.Transform().Equals(expected) {
+		t.Errorf("expected transform %v, got %v", expected, first.Transform())
+	}
+	second := s.world.Objects[1]
+	if m := second.Material(); m.Diffuse != 0.5 || m.Reflective != 0.25 || m.Transparency != 0.75 || m.RefractiveIndex != 1.52 || !m.Color.Equals(canvas.White) {
+		t.Errorf("expected a default material with the given diffuse, reflective, transparency and refractive index, got %+v", m)
+	}
+	if !second.Transform().Equals(geometry.Identity()) {
+		t.Errorf("expected the identity transform, got %v", second.Transform())
+	}
+}
+
+func TestParseSceneTransformOrder(t *testing.T) {
+	input := `
+- add: sphere
+  transform:
+    - [rotate-x, 1.5707963267948966]
+    - [scale, 5, 5, 5]
+    - [translate, 10, 5, 7]
+`
+	s, err := parseScene(strings.NewReader(input), "yaml")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	transform := s.world.Objects[0].Transform()
+	if p := transform.MultiplyTuple(geometry.NewPoint(1, 0, 1)); !p.Equals(geometry.NewPoint(15, 0, 7)) {
+		t.Errorf("expected the transforms to apply in order, got %v", p)
+	}
+	if s.camera != nil {
+		t.Errorf("expected no camera, got %+v", s.camera)
+	}
+}
+
+func TestParseSceneShapes(t *testing.T) {
+	s, err := parseScene(strings.NewReader("- add: sphere\n- add: plane\n- add: cube"), "yaml")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(s.world.Objects) != 3 {
+		t.Fatalf("expected 3 objects, got %d", len(s.world.Objects))
+	}
+	if _, ok := s.world.Objects[0].(*shapes.Sphere); !ok {
+		t.Errorf("expected a sphere, got %T", s.world.Objects[0])
+	}
+	if _, ok := s.world.Objects[1].(*shapes.Plane); !ok {
+		t.Errorf("expected a plane, got %T", s.world.Objects[1])
+	}
+	if _, ok := s.world.Objects[2].(*shapes.Cube); !ok {
+		t.Errorf("expected a cube, got %T", s.world.Objects[2])
+	}
+}
+
+func TestParseSceneErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		input  string
+		errors []string
+	}{
+		{name: "empty", input: "", errors: []string{"empty scene"}},
+		{name: "invalid YAML", input: "- add: [", errors: []string{"invalid YAML"}},
+		{name: "not a list", input: "add: sphere", errors: []string{"line 1, column 1: a scene must be a list"}},
+		{name: "unknown item", input: "- add: cone", errors: []string{`line 1, column 8: unknown item "cone"`}},
+		{name: "no add or define", input: "- value: 1", errors: []string{"line 1, column 3: an item must have an add or define key"}},
+		{
+			name:   "unknown key",
+			input:  "- add: sphere\n  colour: [1, 0, 0]",
+			errors: []string{`line 2, column 3: unknown key "colour"`},
+		},
+		{
+			name:   "missing camera keys",
+			input:  "- add: camera\n  width: 10\n  height: 10\n  field-of-view: 1\n  from: [0, 0, 0]",
+			errors: []string{`line 1, column 3: missing key "to"`, `line 1, column 3: missing key "up"`},
+		},
+		{
+			name:   "invalid tuple",
+			input:  "- add: light\n  at: [1, 2]\n  intensity: [1, 1, 1]",
+			errors: []string{"line 2, column 7: invalid point: invalid input: expected 3 dimensions"},
+		},
+		{
+			name:   "invalid material values",
+			input:  "- add: sphere\n  material:\n    diffuse: 2\n    shininess: shiny\n    glow: 1",
+			errors: []string{"line 3, column 14: expected a number from 0 to 1", `line 4, column 16: expected a number, got "shiny"`, `line 5, column 5: unknown material property "glow"`},
+		},
+		{
+			name:   "numbers that are not finite",
+			input:  "- add: sphere\n  material:\n    ambient: NaN\n  transform:\n    - [translate, Inf, 0, +Inf]",
+			errors: []string{`line 3, column 14: expected a number, got "NaN"`, `line 5, column 19: expected a number, got "Inf"`, `line 5, column 27: expected a number, got "+Inf"`},
+		},
+		{
+			name:   "invalid refractive index",
+			input:  "- add: sphere\n  material:\n    refractive-index: 0",
+			errors: []string{"line 3, column 23: expected a positive number, got 0"},
+		},
+		{
+			name:   "unknown transform",
+			input:  "- add: sphere\n  transform:\n    - [spin, 1]\n    - [translate, 1, 2]",
+			errors: []string{`line 3, column 8: unknown transform "spin"`, "line 4, column 7: translate takes 3 arguments, got 2"},
+		},
+		{
+			name:   "transform not invertible",
+			input:  "- add: sphere\n  transform:\n    - [scale, 0, 1, 1]",
+			errors: []string{"line 3, column 5: invalid transform: matrix is not invertible"},
+		},
+		{
+			name:   "undefined name",
+			input:  "- add: sphere\n  material: shiny",
+			errors: []string{`line 2, column 13: "shiny" is not defined`},
+		},
+		{
+			name:   "definition of the wrong kind",
+			input:  "- define: lifted\n  value:\n    - [translate, 0, 1, 0]\n- add: sphere\n  material: lifted",
+			errors: []string{`line 5, column 13: "lifted" is a transform, not a material`},
+		},
+		{
+			name:   "errors in a definition are reported once",
+			input:  "- define: bad\n  value:\n    ambient: -1\n- add: sphere\n  material: bad\n- add: sphere\n  material: bad",
+			errors: []string{"line 3, column 14: expected a number from 0 to 1"},
+		},
+		{
+			name:   "redefinition",
+			input:  "- define: a\n  value: {}\n- define: a\n  value: {}",
+			errors: []string{`line 3, column 11: "a" is already defined`},
+		},
+		{
+			name:   "two cameras",
+			input:  strings.Repeat("- add: camera\n  width: 10\n  height: 10\n  field-of-view: 1\n  from: [0, 0, 0]\n  to: [0, 0, 1]\n  up: [0, 1, 0]\n", 2),
+			errors: []string{"line 8, column 3: a scene can only have one camera"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := parseScene(strings.NewReader(tt.input), "yaml")
+			if err == nil {
+				t.Fatalf("expected an error")
+			}
+
+			var errs sceneErrors
+			if !errors.As(err, &errs) {
+				if len(tt.errors) != 1 || !strings.Contains(err.Error(), tt.errors[0]) {
+					t.Errorf("expected error containing %q, got %q", tt.errors[0], err.Error())
+				}
+				return
+			}
+			if len(errs) != len(tt.errors) {
+				t.Fatalf("expected %d errors, got %d: %v", len(tt.errors), len(errs), err)
+			}
+			for i, expected := range tt.errors {
+				if !strings.Contains(errs[i].Error(), expected) {
+					t.Errorf("expected error %d containing %q, got %q", i, expected, errs[i].Error())
+				}
+			}
+		})
+	}
+}
+
+func TestParseScenePatterns(t *testing.T) {
+	input := `
+- add: plane
+  material:
+    pattern:
+      type: checkers
+      colors:
+        - [1, 1, 1]
+        - type: stripes
+          colors: ["(1, 0, 0)", [0, 0, 1]]
+          transform: [[scale, 0.25, 1, 1]]
+- add: sphere
+  material:
+    pattern:
+      type: perturb
+      scale: 0
+      pattern:
+        type: gradient
+        colors: [[0, 0, 0], [1, 1, 1]]
+      transform: [[scale, 2, 2, 2]]
+`
+	s, err := parseScene(strings.NewReader(input), "yaml")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	checkers := s.world.Objects[0].Material().Pattern
+	if checkers == nil {
+		t.Fatalf("expected a pattern")
+	}
+	tests := []struct {
+		point    geometry.HomogeneousTuple
+		expected canvas.Color
+	}{
+		{point: geometry.NewPoint(0.5, 0, 0.5), expected: canvas.White},
+		{point: geometry.NewPoint(1.1, 0, 0.5), expected: canvas.NewColor(1, 0, 0)},
+		{point: geometry.NewPoint(1.3, 0, 0.5), expected: canvas.NewColor(0, 0, 1)},
+	}
+	for _, tt := range tests {
+		if c := shading.PatternAt(checkers, tt.point); !c.Equals(tt.expected) {
+			t.Errorf("expected %v at %v, got %v", tt.expected, tt.point, c)
+		}
+	}
+
+	perturbed := s.world.Objects[1].Material().Pattern
+	if c := shading.PatternAt(perturbed, geometry.NewPoint(1, 0, 0)); !c.Equals(canvas.NewColor(0.5, 0.5, 0.5)) {
+		t.Errorf("expected the scaled gradient to be half way at x=1, got %v", c)
+	}
+}
+
+func TestParseScenePatternErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		input  string
+		errors []string
+	}{
+		{name: "not a mapping", input: "- add: sphere\n  material: {pattern: stripes}", errors: []string{"a pattern must be a mapping"}},
+		{name: "missing type", input: "- add: sphere\n  material: {pattern: {colors: []}}", errors: []string{`missing key "type"`}},
+		{name: "unknown type", input: "- add: sphere\n  material: {pattern: {type: plaid}}", errors: []string{`unknown pattern type "plaid"`}},
+		{name: "one color", input: "- add: sphere\n  material: {pattern: {type: rings, colors: [[1, 1, 1]]}}", errors: []string{"a rings pattern needs a list of two colors or patterns"}},
+		{name: "key of another type", input: "- add: sphere\n  material: {pattern: {type: rings, colors: [[1, 1, 1], [0, 0, 0]], scale: 1}}", errors: []string{`unknown key "scale"`}},
+		{
+			name:   "errors in nested patterns",
+			input:  "- add: sphere\n  material: {pattern: {type: blend, colors: [[1, 1], {type: perturb, pattern: {type: gradient, colors: [[0, 0, 0], [1, 1, 1]]}, scale: -1}]}}",
+			errors: []string{"invalid color", "expected a number that is not negative"},
+		},
+		{
+			name:   "transform not invertible",
+			input:  "- add: sphere\n  material: {pattern: {type: stripes, colors: [[1, 1, 1], [0, 0, 0]], transform: [[scale, 0, 0, 0]]}}",
+			errors: []string{"invalid transform: matrix is not invertible"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := parseScene(strings.NewReader(tt.input), "yaml")
+			var errs sceneErrors
+			if !errors.As(err, &errs) {
+				t.Fatalf("expected scene errors, got %v", err)
+			}
+			if len(errs) != len(tt.errors) {
+				t.Fatalf("expected %d errors, got %d: %v", len(tt.errors), len(errs), err)
+			}
+			for i, expected := range tt.errors {
+				if !strings.Contains(errs[i].Error(), expected) {
+					t.Errorf("expected error %d containing %q, got %q", i, expected, errs[i].Error())
+				}
+			}
+		})
+	}
+}
+
+func TestParseSceneJSON(t *testing.T) {
+	yamlScene := `
+- add: light
+  at: [-10, 10, -10]
+  intensity: [1, 1, 1]
+- define: shiny
+  value: {color: [1, 0.2, 1], specular: 0.9}
+- add: sphere
+  material: shiny
+  transform: [[scale, 0.5, 0.5, 0.5], [translate, 0, 1, 0]]
+`
+	jsonScene := `[
+	{"add": "light", "at": [-10, 10, -10], "intensity": "(1, 1, 1)"},
+	{"define": "shiny", "value": {"color": [1, 0.2, 1], "specular": 0.9}},
+	{"add": "sphere", "material": "shiny", "transform": [["scale", 0.5, 0.5, 0.5], ["translate", 0, 1, 0]]}
+]`
+	fromYAML, err := parseScene(strings.NewReader(yamlScene), "yaml")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	fromJSON, err := parseScene(strings.NewReader(jsonScene), "json")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if len(fromJSON.world.Lights) != 1 || fromJSON.world.Lights[0] != fromYAML.world.Lights[0] {
+		t.Errorf("expected the same light, got %+v and %+v", fromJSON.world.Lights, fromYAML.world.Lights)
+	}
+	if len(fromJSON.world.Objects) != 1 {
+		t.Fatalf("expected 1 object, got %d", len(fromJSON.world.Objects))
+	}
+	a, b := fromJSON.world.Objects[0], fromYAML.world.Objects[0]
+	if a.Material() != b.Material() || !a.Transform().Equals(b.Transform()) {
+		t.Errorf("expected the same sphere, got %+v, %v and %+v, %v", a.Material(), a.Transform(), b.Material(), b.Transform())
+	}
+}
+
+func TestParseScenePointers(t *testing.T) {
+	input := `[
+  {"add": "sphere", "material": {"diffuse": 2}},
+  {"define": "a/b~c", "value": {"specular": -1}},
+  {"add": "light", "at": [0, 0, 0], "intensity": [1, 1]}
+]`
+	_, err := parseScene(strings.NewReader(input), "json")
+	var errs sceneErrors
+	if !errors.As(err, &errs) {
+		t.Fatalf("expected scene errors, got %v", err)
+	}
+
+	expected := []sceneError{
+		{line: 2, column: 45, pointer: "/0/material/diffuse", message: "expected a number from 0 to 1, got 2"},
+		{line: 3, column: 45, pointer: "/1/value/specular", message: "expected a number from 0 to 1, got -1"},
+		{line: 4, column: 50, pointer: "/2/intensity", message: "invalid color: invalid input: expected 3 dimensions"},
+	}
+	if len(errs) != len(expected) {
+		t.Fatalf("expected %d errors, got %d: %v", len(expected), len(errs), err)
+	}
+	for i := range expected {
+		if errs[i] != expected[i] {
+			t.Errorf("expected error %+v, got %+v", expected[i], errs[i])
+		}
+	}
+}
+
+func TestParseSceneFormatErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		input  string
+		format string
+		error  string
+	}{
+		{name: "JSON syntax error", input: "[\n  {\"add\": \"sphere\",}\n]", format: "json", error: "invalid JSON: line 2, column 20"},
+		{name: "YAML given as JSON", input: "- add: sphere", format: "json", error: "invalid JSON"},
+		{name: "empty JSON", input: "", format: "json", error: "invalid JSON"},
+		{name: "unknown format", input: "[]", format: "toml", error: `unknown scene format "toml"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := parseScene(strings.NewReader(tt.input), tt.format)
+			if err == nil || !strings.Contains(err.Error(), tt.error) {
+				t.Errorf("expected error containing %q, got %v", tt.error, err)
+			}
+		})
+	}
+}
+
+func TestSceneFormatForPath(t *testing.T) {
+	tests := map[string]string{
+		"scene.json": "json",
+		"SCENE.JSON": "json",
+		"scene.yaml": "yaml",
+		"scene.yml":  "yaml",
+		"scene":      "yaml",
+	}
+	for path, expected := range tests {
+		if got := sceneFormatForPath(path); got != expected {
+			t.Errorf("sceneFormatForPath(%q) = %q, want %q", path, got, expected)
+		}
+	}
+}
+
+func TestCameraSettingsNewCamera(t *testing.T) {
+	settings := cameraSettings{
+		width:       20,
+		height:      10,
+		fieldOfView: math.Pi / 2,
+		from:        geometry.NewPoint(0, 0, -5),
+		to:          geometry.NewPoint(0, 0, 0),
+		up:          geometry.NewVector(0, 1, 0),
+	}
+	camera, err := settings.newCamera()
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if camera.HSize() != 20 || camera.VSize() != 10 {
+		t.Errorf("expected a 20x10 camera, got %dx%d", camera.HSize(), camera.VSize())
+	}
+
+	settings.up = geometry.NewVector(0, 0, 1) // parallel to the view direction
+	if _, err := settings.newCamera(); err == nil {
+		t.Errorf("expected an error for an up vector parallel to the view direction")
+	}
+}
@@ -0,0 +1,133 @@
+package cmd
+
+import (
+	"encoding/json"
+	"maps"
+	"math"
+	"slices"
+
+	"github.com/spf13/cobra"
+)
+
+// sceneSchemaCmd represents the scene schema command
+var sceneSchemaCmd = &cobra.Command{
+	Use:   "schema",
+	Short: "Print the JSON Schema of scene files",
+	Long: `This command prints the JSON Schema (draft 2020-12) that describes JSON scene files.
+The schema also describes YAML scene files, which have the same structure.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		encoder := json.NewEncoder(cmd.OutOrStdout())
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(sceneSchema())
+	},
+}
+
+// sceneSchema builds the JSON Schema of scene files from the same tables that parseScene reads them with.
+func sceneSchema() map[string]any {
+	materialSchema := map[string]any{}
+	for name, property := range materialProperties {
+		materialSchema[name] = property.schema
+	}
+
+	var operations []any
+	for _, name := range slices.Sorted(maps.Keys(transformArguments)) {
+		items := []any{map[string]any{"const": name}}
+		for range transformArguments[name] {
+			items = append(items, map[string]any{"type": "number"})
+		}
+		operations = append(operations, map[string]any{
+			"type":        "array",
+			"prefixItems": items,
+			"minItems":    len(items),
+			"maxItems":    len(items),
+		})
+	}
+
+	name := map[string]any{"type": "string", "minLength": 1}
+	return map[string]any{
+		"$schema":     "https://json-schema.org/draft/2020-12/schema",
+		"title":       "go-for-rays scene",
+		"description": "A list of items, each of which adds a camera, light or shape to the scene, or defines a reusable material or transform.",
+		"type":        "array",
+		"items": map[string]any{
+			"oneOf": []any{schemaRef("camera"), schemaRef("light"), schemaRef("shape"), schemaRef("definition")},
+		},
+		"$defs": map[string]any{
+			"camera": schemaObject(map[string]any{
+				"add":           map[string]any{"const": "camera"},
+				"width":         map[string]any{"type": "integer", "minimum": 1, "maximum": math.MaxInt32},
+				"height":        map[string]any{"type": "integer", "minimum": 1, "maximum": math.MaxInt32},
+				"field-of-view": map[string]any{"type": "number", "exclusiveMinimum": 0, "exclusiveMaximum": math.Pi, "description": "in radians"},
+				"from":          schemaRef("tuple"),
+				"to":            schemaRef("tuple"),
+				"up":            schemaRef("tuple"),
+			}, "add", "width", "height", "field-of-view", "from", "to", "up"),
+			"light": schemaObject(map[string]any{
+				"add":       map[string]any{"const": "light"},
+				"at":        schemaRef("tuple"),
+				"intensity": schemaRef("color"),
+			}, "add", "at", "intensity"),
+			"shape": schemaObject(map[string]any{
+				"add":       map[string]any{"enum": slices.Sorted(maps.Keys(sceneShapes))},
+				"material":  map[string]any{"oneOf": []any{name, schemaRef("material")}},
+				"transform": map[string]any{"oneOf": []any{name, schemaRef("transform")}},
+			}, "add"),
+			"definition": schemaObject(map[string]any{
+				"define": name,
+				"extend": name,
+				"value":  map[string]any{"oneOf": []any{schemaRef("material"), schemaRef("transform")}},
+			}, "define", "value"),
+			"material":  schemaObject(materialSchema),
+			"transform": map[string]any{"type": "array", "items": map[string]any{"oneOf": append([]any{name}, operations...)}},
+			"tuple": map[string]any{"oneOf": []any{
+				map[string]any{"type": "array", "items": map[string]any{"type": "number"}, "minItems": 3, "maxItems": 3},
+				map[string]any{"type": "string", "pattern": tupleMatcher.String()},
+			}},
+			"pattern":  patternSchema(),
+			"color":    schemaRef("tuple"),
+			"fraction": map[string]any{"type": "number", "minimum": 0, "maximum": 1},
+		},
+	}
+}
+
+// patternSchema describes the patterns of materials, which may nest.
+func patternSchema() map[string]any {
+	transform := map[string]any{"oneOf": []any{map[string]any{"type": "string", "minLength": 1}, schemaRef("transform")}}
+	part := map[string]any{"oneOf": []any{schemaRef("color"), schemaRef("pattern")}}
+	return map[string]any{"oneOf": []any{
+		schemaObject(map[string]any{
+			"type":      map[string]any{"enum": slices.Sorted(maps.Keys(scenePatterns))},
+			"colors":    map[string]any{"type": "array", "items": part, "minItems": 2, "maxItems": 2},
+			"transform": transform,
+		}, "type", "colors"),
+		schemaObject(map[string]any{
+			"type":      map[string]any{"const": "perturb"},
+			"pattern":   schemaRef("pattern"),
+			"scale":     map[string]any{"type": "number", "minimum": 0, "default": defaultPerturbScale},
+			"transform": transform,
+		}, "type", "pattern"),
+	}}
+}
+
+// schemaRef refers to one of the definitions of the scene schema.
+func schemaRef(name string) map[string]any {
+	return map[string]any{"$ref": "#/$defs/" + name}
+}
+
+// schemaObject describes an object with the given properties and no others.
+func schemaObject(properties map[string]any, required ...string) map[string]any {
+	schema := map[string]any{
+		"type":                 "object",
+		"properties":           properties,
+		"additionalProperties": false,
+	}
+	if len(required) > 0 {
+		schema["required"] = required
+	}
+	return schema
+}
+
+func init() {
+	sceneCmd.AddCommand(sceneSchemaCmd)
+}
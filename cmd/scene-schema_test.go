@@ -0,0 +1,220 @@
+package cmd
+
+import (
+	"cmp"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"math"
+	"regexp"
+	"slices"
+	"strings"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+func TestSceneSchema(t *testing.T) {
+	data, err := json.Marshal(sceneSchema())
+	if err != nil {
+		t.Fatalf("expected the schema to encode, got %v", err)
+	}
+
+	var schema struct {
+		Defs map[string]struct {
+			Properties map[string]json.RawMessage `json:"properties"`
+			Required   []string                   `json:"required"`
+		} `json:"$defs"`
+	}
+	if err := json.Unmarshal(data, &schema); err != nil {
+		t.Fatalf("expected the schema to decode, got %v", err)
+	}
+
+	for _, name := range []string{"camera", "light", "shape", "definition", "material", "pattern", "transform", "tuple", "color", "fraction"} {
+		if _, ok := schema.Defs[name]; !ok {
+			t.Errorf("expected a definition of %q", name)
+		}
+	}
+
+	material := schema.Defs["material"].Properties
+	if got, want := slices.Sorted(maps.Keys(material)), slices.Sorted(maps.Keys(materialProperties)); !slices.Equal(got, want) {
+		t.Errorf("expected material properties %v, got %v", want, got)
+	}
+
+	var shape struct {
+		Enum []string `json:"enum"`
+	}
+	if err := json.Unmarshal(schema.Defs["shape"].Properties["add"], &shape); err != nil {
+		t.Fatalf("expected the shape names to decode, got %v", err)
+	}
+	if want := slices.Sorted(maps.Keys(sceneShapes)); !slices.Equal(shape.Enum, want) {
+		t.Errorf("expected shapes %v, got %v", want, shape.Enum)
+	}
+
+	if required := schema.Defs["camera"].Required; len(required) != 7 {
+		t.Errorf("expected every camera key to be required, got %v", required)
+	}
+}
+
+func TestSceneSchemaAgreesWithParseScene(t *testing.T) {
+	camera := func(width, fieldOfView string) string {
+		return fmt.Sprintf(`[{"add": "camera", "width": %s, "height": 50, "field-of-view": %s, "from": [0, 1.5, -5], "to": [0, 1, 0], "up": [0, 1, 0]}]`, width, fieldOfView)
+	}
+	tests := []struct {
+		name   string
+		input  string
+		format string // default: json
+		valid  bool
+	}{
+		{name: "camera", input: camera("100", "1.0472"), valid: true},
+		{name: "whole number with a zero fraction", input: camera("100.0", "1"), valid: true},
+		{name: "exponents in tuples", input: `[{"add": "sphere", "material": {"color": [1e-1, 0.5, 0.5], "ambient": 1e-07}, "transform": [["translate", 1e-07, 0, 0]]}, {"add": "light", "at": "(1e2, 0, -1E+1)", "intensity": [1, 1, 1]}]`, valid: true},
+		{name: "numbers with a trailing point", input: `[{"add": "light", "at": [1., 2, 3], "intensity": [1, 1, 1]}]`, format: "yaml", valid: true},
+		{name: "definitions", input: `[{"define": "shiny", "value": {"specular": 0.9}}, {"define": "lifted", "value": [["translate", 0, 1, 0]]}, {"add": "cube", "material": "shiny", "transform": ["lifted"]}]`, valid: true},
+		{name: "width as a string", input: camera(`"100"`, "1.0472"), valid: false},
+		{name: "width with a fraction", input: camera("100.5", "1.0472"), valid: false},
+		{name: "width of zero", input: camera("0", "1.0472"), valid: false},
+		{name: "field of view as a string", input: camera("100", `"1.0"`), valid: false},
+		{name: "field of view of pi", input: camera("100", "3.2"), valid: false},
+		{name: "fraction as a string", input: `[{"add": "sphere", "material": {"ambient": "0.5"}}]`, valid: false},
+		{name: "fraction of NaN", input: `[{"add": "sphere", "material": {"diffuse": "NaN"}}]`, valid: false},
+		{name: "fraction out of range", input: `[{"add": "sphere", "material": {"reflective": 1.5}}]`, valid: false},
+		{name: "transform argument as a string", input: `[{"add": "sphere", "transform": [["translate", "Inf", 0, 0]]}]`, valid: false},
+		{name: "tuple component as a string", input: `[{"add": "light", "at": ["1", 0, 0], "intensity": [1, 1, 1]}]`, valid: false},
+		{name: "tuple of two", input: `[{"add": "light", "at": [1, 0], "intensity": [1, 1, 1]}]`, valid: false},
+		{name: "definition name as a number", input: `[{"define": 5, "value": {}}]`, valid: false},
+		{name: "empty definition name", input: `[{"define": "", "value": {}}]`, valid: false},
+		{name: "unknown key", input: `[{"add": "sphere", "colour": [1, 0, 0]}]`, valid: false},
+	}
+
+	data, err := json.Marshal(sceneSchema())
+	if err != nil {
+		t.Fatalf("expected the schema to encode, got %v", err)
+	}
+	var schema map[string]any
+	if err := json.Unmarshal(data, &schema); err != nil {
+		t.Fatalf("expected the schema to decode, got %v", err)
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			format, data := cmp.Or(tt.format, "json"), []byte(tt.input)
+			if format == "yaml" {
+				// the schema applies to YAML as decoded into the same values as JSON
+				var decoded any
+				if err := yaml.Unmarshal(data, &decoded); err != nil {
+					t.Fatalf("expected valid YAML, got %v", err)
+				}
+				if data, err = json.Marshal(decoded); err != nil {
+					t.Fatalf("expected the YAML to encode as JSON, got %v", err)
+				}
+			}
+			var document any
+			if err := json.Unmarshal(data, &document); err != nil {
+				t.Fatalf("expected valid JSON, got %v", err)
+			}
+			if got := schemaAccepts(schema, schema, document); got != tt.valid {
+				t.Errorf("expected the schema to accept the scene: %v, got %v", tt.valid, got)
+			}
+			if _, err := parseScene(strings.NewReader(tt.input), format); (err == nil) != tt.valid {
+				t.Errorf("expected parseScene to accept the scene: %v, got error %v", tt.valid, err)
+			}
+		})
+	}
+}
+
+// schemaAccepts checks a decoded JSON value against a schema, with just the keywords sceneSchema uses.
+func schemaAccepts(root, schema map[string]any, value any) bool {
+	if ref, ok := schema["$ref"].(string); ok {
+		definition := root["$defs"].(map[string]any)[strings.TrimPrefix(ref, "#/$defs/")]
+		return schemaAccepts(root, definition.(map[string]any), value)
+	}
+	if oneOf, ok := schema["oneOf"].([]any); ok {
+		matches := 0
+		for _, option := range oneOf {
+			if schemaAccepts(root, option.(map[string]any), value) {
+				matches++
+			}
+		}
+		if matches != 1 {
+			return false
+		}
+	}
+	if constant, ok := schema["const"]; ok && constant != value {
+		return false
+	}
+	if enum, ok := schema["enum"].([]any); ok && !slices.Contains(enum, value) {
+		return false
+	}
+
+	switch kind, _ := schema["type"].(string); kind {
+	case "number", "integer":
+		number, ok := value.(float64)
+		if !ok || (kind == "integer" && number != math.Trunc(number)) {
+			return false
+		}
+		if limit, ok := schema["minimum"].(float64); ok && number < limit {
+			return false
+		}
+		if limit, ok := schema["maximum"].(float64); ok && number > limit {
+			return false
+		}
+		if limit, ok := schema["exclusiveMinimum"].(float64); ok && number <= limit {
+			return false
+		}
+		if limit, ok := schema["exclusiveMaximum"].(float64); ok && number >= limit {
+			return false
+		}
+	case "string":
+		text, ok := value.(string)
+		if !ok {
+			return false
+		}
+		if limit, ok := schema["minLength"].(float64); ok && float64(len(text)) < limit {
+			return false
+		}
+		if pattern, ok := schema["pattern"].(string); ok && !regexp.MustCompile(pattern).MatchString(text) {
+			return false
+		}
+	case "array":
+		items, ok := value.([]any)
+		if !ok {
+			return false
+		}
+		if limit, ok := schema["minItems"].(float64); ok && float64(len(items)) < limit {
+			return false
+		}
+		if limit, ok := schema["maxItems"].(float64); ok && float64(len(items)) > limit {
+			return false
+		}
+		prefix, _ := schema["prefixItems"].([]any)
+		for i, item := range items {
+			itemSchema, ok := schema["items"].(map[string]any)
+			if i < len(prefix) {
+				itemSchema, ok = prefix[i].(map[string]any), true
+			}
+			if ok && !schemaAccepts(root, itemSchema, item) {
+				return false
+			}
+		}
+	case "object":
+		object, ok := value.(map[string]any)
+		if !ok {
+			return false
+		}
+		required, _ := schema["required"].([]any)
+		for _, key := range required {
+			if _, ok := object[key.(string)]; !ok {
+				return false
+			}
+		}
+		properties := schema["properties"].(map[string]any)
+		for key, property := range object {
+			propertySchema, known := properties[key]
+			if !known || !schemaAccepts(root, propertySchema.(map[string]any), property) {
+				return false
+			}
+		}
+	}
+	return true
+}
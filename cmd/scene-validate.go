@@ -0,0 +1,105 @@
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+
+	"github.com/spf13/cobra"
+)
+
+// sceneValidateCmd represents the scene validate command
+var sceneValidateCmd = &cobra.Command{
+	Use:   "validate <file>...",
+	Short: "Check scene files for errors",
+	Long: `This command reads scene files and reports every error they contain, rather than stopping at the first.
+Each error is located by its line and column, and by the JSON Pointer to the offending value.
+With --format json, the errors are printed as a JSON array for other tools to consume.`,
+	Args:         cobra.MinimumNArgs(1),
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		format, _ := cmd.Flags().GetString("format")
+		if format != "text" && format != "json" {
+			return fmt.Errorf("invalid format: %q is not one of text, json", format)
+		}
+
+		problems := []sceneProblem{}
+		for _, path := range args {
+			problems = append(problems, validateSceneFile(path)...)
+		}
+
+		w := cmd.OutOrStdout()
+		if format == "json" {
+			encoder := json.NewEncoder(w)
+			encoder.SetIndent("", "  ")
+			if err := encoder.Encode(problems); err != nil {
+				return err
+			}
+		} else {
+			printSceneProblems(w, problems)
+		}
+
+		if len(problems) > 0 {
+			return fmt.Errorf("invalid scenes: %d problem(s) found", len(problems))
+		}
+		return nil
+	},
+}
+
+// sceneProblem is an error found in a scene file, as reported by scene validate.
+// Problems that cannot be located in the file, such as a file that cannot be read, have no line or pointer.
+type sceneProblem struct {
+	File    string  `json:"file"`
+	Line    int     `json:"line,omitempty"`
+	Column  int     `json:"column,omitempty"`
+	Pointer *string `json:"pointer,omitempty"`
+	Message string  `json:"message"`
+}
+
+// validateSceneFile returns every problem in a scene file.
+func validateSceneFile(path string) []sceneProblem {
+	file, err := os.Open(path)
+	if err != nil {
+		return []sceneProblem{{File: path, Message: err.Error()}}
+	}
+	defer file.Close()
+
+	_, err = parseScene(file, sceneFormatForPath(path))
+	var errs sceneErrors
+	if errors.As(err, &errs) {
+		problems := make([]sceneProblem, len(errs))
+		for i, e := range errs {
+			pointer := e.pointer
+			problems[i] = sceneProblem{File: path, Line: e.line, Column: e.column, Pointer: &pointer, Message: e.message}
+		}
+		return problems
+	}
+	if err != nil {
+		return []sceneProblem{{File: path, Message: err.Error()}}
+	}
+	return nil
+}
+
+// printSceneProblems writes one problem per line, as file:line:column: "pointer": message.
+func printSceneProblems(w io.Writer, problems []sceneProblem) {
+	for _, p := range problems {
+		location := p.File
+		if p.Line > 0 {
+			location = fmt.Sprintf("%s:%d:%d", p.File, p.Line, p.Column)
+		}
+		if p.Pointer != nil {
+			fmt.Fprintf(w, "%s: %s: %s\n", location, strconv.Quote(*p.Pointer), p.Message)
+		} else {
+			fmt.Fprintf(w, "%s: %s\n", location, p.Message)
+		}
+	}
+}
+
+func init() {
+	sceneCmd.AddCommand(sceneValidateCmd)
+
+	sceneValidateCmd.Flags().String("format", "text", "Output format: text or json")
+}
@@ -0,0 +1,63 @@
+package cmd
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestValidateSceneFile(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name, content string) string {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatalf("cannot write %s: %v", name, err)
+		}
+		return path
+	}
+
+	valid := write("valid.json", `[{"add": "sphere"}]`)
+	if problems := validateSceneFile(valid); len(problems) != 0 {
+		t.Errorf("expected no problems, got %+v", problems)
+	}
+
+	invalid := write("invalid.yaml", "- add: sphere\n  material: {ambient: 2}\n- add: cone\n")
+	problems := validateSceneFile(invalid)
+	if len(problems) != 2 {
+		t.Fatalf("expected 2 problems, got %+v", problems)
+	}
+	if p := problems[0]; p.Line != 2 || p.Column != 23 || p.Pointer == nil || *p.Pointer != "/0/material/ambient" {
+		t.Errorf("expected a problem at line 2, column 23, /0/material/ambient, got %+v", p)
+	}
+	if p := problems[1]; p.Pointer == nil || *p.Pointer != "/1/add" {
+		t.Errorf("expected a problem at /1/add, got %+v", p)
+	}
+
+	missing := filepath.Join(dir, "missing.yaml")
+	if problems := validateSceneFile(missing); len(problems) != 1 || problems[0].Pointer != nil || problems[0].Line != 0 {
+		t.Errorf("expected one unlocated problem, got %+v", problems)
+	}
+}
+
+func TestPrintSceneProblems(t *testing.T) {
+	pointer := "/0/add"
+	root := ""
+	problems := []sceneProblem{
+		{File: "a.yaml", Line: 1, Column: 8, Pointer: &pointer, Message: "unknown item"},
+		{File: "b.json", Line: 1, Column: 1, Pointer: &root, Message: "a scene must be a list of items"},
+		{File: "c.json", Message: "invalid JSON"},
+	}
+
+	var buf bytes.Buffer
+	printSceneProblems(&buf, problems)
+	expected := strings.Join([]string{
+		`a.yaml:1:8: "/0/add": unknown item`,
+		`b.json:1:1: "": a scene must be a list of items`,
+		`c.json: invalid JSON`,
+	}, "\n") + "\n"
+	if buf.String() != expected {
+		t.Errorf("expected\n%s\ngot\n%s", expected, buf.String())
+	}
+}
@@ -0,0 +1,17 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+)
+
+// sceneCmd represents the scene command
+var sceneCmd = &cobra.Command{
+	Use:   "scene",
+	Short: "Work with scene files",
+	Long: `Scene files describe the camera, lights, shapes, materials and transforms to render, in YAML or JSON.
+Files ending in .json are read as JSON, and any other file as YAML; both formats have the same structure.`,
+}
+
+func init() {
+	rootCmd.AddCommand(sceneCmd)
+}
@@ -77,7 +77,7 @@ func resolveTupleOptions(options ...parseTupleOptions) parseTupleOptions {
 	return opts
 }
 
-var tupleMatcher = regexp.MustCompile(`^\s*\(?\s*([-+]?\d*\.?\d+)\s*,\s*([-+]?\d*\.?\d+)(?:\s*,\s*([-+]?\d*\.?\d+))?\s*\)?\s*$`)
+var tupleMatcher = regexp.MustCompile(`^\s*\(?\s*([-+]?\d*\.?\d+(?:[eE][-+]?\d+)?)\s*,\s*([-+]?\d*\.?\d+(?:[eE][-+]?\d+)?)(?:\s*,\s*([-+]?\d*\.?\d+(?:[eE][-+]?\d+)?))?\s*\)?\s*$`)
 
 func extractComponentsFromTupleText(input string, expectedDimensions int) ([]string, error) {
 	parts := tupleMatcher.FindStringSubmatch(input)
@@ -33,3 +33,13 @@ func TestParsePoint(t *testing.T) {
 		t.Errorf("expected Point(1,2,3), got %s", tuple.String())
 	}
 }
+
+func TestParseTupleExponent(t *testing.T) {
+	tuple, err := parseTuple("(1e-07, 2.5E+1, -3e0)")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if tuple.X() != 1e-7 || tuple.Y() != 25 || tuple.Z() != -3 {
+		t.Errorf("expected Vector(1e-07,25,-3), got %s", tuple.String())
+	}
+}
@@ -0,0 +1,54 @@
+package canvas
+
+// Canvas is a rectangular grid of pixels, addressed by (x, y) with the origin at the top left.
+type Canvas struct {
+	width, height int
+	pixels        []Color
+}
+
+// NewCanvas creates a canvas of the given size with every pixel black.
+func NewCanvas(width, height int) *Canvas {
+	width, height = max(width, 0), max(height, 0)
+	return &Canvas{
+		width:  width,
+		height: height,
+		pixels: make([]Color, width*height),
+	}
+}
+
+func (c *Canvas) Width() int {
+	return c.width
+}
+
+func (c *Canvas) Height() int {
+	return c.height
+}
+
+// Contains reports whether (x, y) addresses a pixel on the canvas.
+func (c *Canvas) Contains(x, y int) bool {
+	return x >= 0 && x < c.width && y >= 0 && y < c.height
+}
+
+// WritePixel sets the color of the pixel at (x, y).
+// Writes outside the canvas are ignored, so callers can draw shapes that are partly off the canvas.
+func (c *Canvas) WritePixel(x, y int, color Color) {
+	if !c.Contains(x, y) {
+		return
+	}
+	c.pixels[y*c.width+x] = color
+}
+
+// PixelAt returns the color of the pixel at (x, y), or Black if it is outside the canvas.
+func (c *Canvas) PixelAt(x, y int) Color {
+	if !c.Contains(x, y) {
+		return Black
+	}
+	return c.pixels[y*c.width+x]
+}
+
+// Fill sets every pixel of the canvas to the given color.
+func (c *Canvas) Fill(color Color) {
+	for i := range c.pixels {
+		c.pixels[i] = color
+	}
+}
@@ -0,0 +1,42 @@
+package canvas
+
+import (
+	"testing"
+)
+
+func TestNewCanvas(t *testing.T) {
+	c := NewCanvas(10, 20)
+	if c.Width() != 10 || c.Height() != 20 {
+		t.Fatalf("NewCanvas() size = %dx%d, want 10x20", c.Width(), c.Height())
+	}
+	for y := 0; y < c.Height(); y++ {
+		for x := 0; x < c.Width(); x++ {
+			if got := c.PixelAt(x, y); got != Black {
+				t.Fatalf("PixelAt(%d, %d) = %v, want %v", x, y, got, Black)
+			}
+		}
+	}
+}
+
+func TestWritePixel(t *testing.T) {
+	red := NewColor(1, 0, 0)
+	tests := []struct {
+		name     string
+		x, y     int
+		expected Color
+	}{
+		{name: "inside the canvas", x: 2, y: 3, expected: red},
+		{name: "left of the canvas is ignored", x: -1, y: 3, expected: Black},
+		{name: "below the canvas is ignored", x: 2, y: 20, expected: Black},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := NewCanvas(10, 20)
+			c.WritePixel(tt.x, tt.y, red)
+			if got := c.PixelAt(tt.x, tt.y); got != tt.expected {
+				t.Errorf("PixelAt(%d, %d) = %v, want %v", tt.x, tt.y, got, tt.expected)
+			}
+		})
+	}
+}
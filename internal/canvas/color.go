@@ -0,0 +1,77 @@
+package canvas
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/seanpk/go-for-rays/internal/geometry"
+)
+
+// Color is an RGB color whose components are nominally in the range [0, 1].
+// Components outside that range are allowed during computation and are clamped when written out.
+type Color struct {
+	r, g, b float64
+}
+
+func NewColor(r, g, b float64) Color {
+	return Color{r: r, g: g, b: b}
+}
+
+var (
+	Black = NewColor(0, 0, 0)
+	White = NewColor(1, 1, 1)
+)
+
+func (c Color) R() float64 {
+	return c.r
+}
+
+func (c Color) G() float64 {
+	return c.g
+}
+
+func (c Color) B() float64 {
+	return c.b
+}
+
+// Add returns the component-wise sum of the colors.
+func (c Color) Add(other Color) Color {
+	return NewColor(c.r+other.r, c.g+other.g, c.b+other.b)
+}
+
+// Subtract returns the component-wise difference of the colors.
+func (c Color) Subtract(other Color) Color {
+	return NewColor(c.r-other.r, c.g-other.g, c.b-other.b)
+}
+
+// Multiply returns the color with each component multiplied by the scalar.
+func (c Color) Multiply(scalar float64) Color {
+	return NewColor(c.r*scalar, c.g*scalar, c.b*scalar)
+}
+
+// Hadamard returns the component-wise (Hadamard) product of the colors,
+// e.g. the color of a surface as seen under a colored light.
+func (c Color) Hadamard(other Color) Color {
+	return NewColor(c.r*other.r, c.g*other.g, c.b*other.b)
+}
+
+func (c Color) Equals(other Color, epsilon ...float64) bool {
+	return geometry.IsNearTo(c.r, other.r, epsilon...) &&
+		geometry.IsNearTo(c.g, other.g, epsilon...) &&
+		geometry.IsNearTo(c.b, other.b, epsilon...)
+}
+
+func (c Color) String() string {
+	return fmt.Sprintf("Color(%f, %f, %f)", c.R(), c.G(), c.B())
+}
+
+// scaleComponent maps a color component from [0, 1] to [0, max], clamping values outside that range.
+func scaleComponent(v float64, max int) int {
+	if !(v > 0) {
+		return 0 // also catches NaN
+	}
+	if v >= 1 {
+		return max
+	}
+	return int(math.Round(v * float64(max)))
+}
@@ -0,0 +1,51 @@
+package canvas
+
+import (
+	"testing"
+)
+
+func TestColorOperations(t *testing.T) {
+	c1 := NewColor(0.9, 0.6, 0.75)
+	c2 := NewColor(0.7, 0.1, 0.25)
+
+	tests := []struct {
+		name     string
+		got      Color
+		expected Color
+	}{
+		{name: "add", got: c1.Add(c2), expected: NewColor(1.6, 0.7, 1.0)},
+		{name: "subtract", got: c1.Subtract(c2), expected: NewColor(0.2, 0.5, 0.5)},
+		{name: "multiply by a scalar", got: NewColor(0.2, 0.3, 0.4).Multiply(2), expected: NewColor(0.4, 0.6, 0.8)},
+		{name: "hadamard product", got: NewColor(1, 0.2, 0.4).Hadamard(NewColor(0.9, 1, 0.1)), expected: NewColor(0.9, 0.2, 0.04)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if !tt.got.Equals(tt.expected) {
+				t.Errorf("got %v, want %v", tt.got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestColorEquality(t *testing.T) {
+	tests := []struct {
+		name     string
+		a, b     Color
+		epsilon  []float64
+		expected bool
+	}{
+		{name: "equal", a: NewColor(0.1, 0.2, 0.3), b: NewColor(0.1, 0.2, 0.3), expected: true},
+		{name: "within epsilon", a: NewColor(0.1, 0.2, 0.3), b: NewColor(0.1000001, 0.2, 0.3), expected: true},
+		{name: "different", a: NewColor(0.1, 0.2, 0.3), b: NewColor(0.1, 0.2, 0.31), expected: false},
+		{name: "within a custom epsilon", a: NewColor(0.1, 0.2, 0.3), b: NewColor(0.1, 0.2, 0.31), epsilon: []float64{0.1}, expected: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.a.Equals(tt.b, tt.epsilon...); got != tt.expected {
+				t.Errorf("Equals() = %v, want %v", got, tt.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,58 @@
+package canvas
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"image/png"
+	"io"
+	"path/filepath"
+	"strings"
+)
+
+// Image converts the canvas to an image from the standard library.
+func (c *Canvas) Image() *image.RGBA {
+	img := image.NewRGBA(image.Rect(0, 0, c.width, c.height))
+	for y := 0; y < c.height; y++ {
+		for x := 0; x < c.width; x++ {
+			pixel := c.PixelAt(x, y)
+			img.SetRGBA(x, y, color.RGBA{
+				R: uint8(scaleComponent(pixel.R(), 255)),
+				G: uint8(scaleComponent(pixel.G(), 255)),
+				B: uint8(scaleComponent(pixel.B(), 255)),
+				A: 255,
+			})
+		}
+	}
+	return img
+}
+
+// WritePNG writes the canvas as a PNG image.
+func (c *Canvas) WritePNG(w io.Writer) error {
+	return png.Encode(w, c.Image())
+}
+
+// Formats lists the image formats supported by Write.
+var Formats = []string{"p3", "p6", "png"}
+
+// Write writes the canvas in the named image format: p3 (plain PPM), p6 (binary PPM) or png.
+func (c *Canvas) Write(w io.Writer, format string) error {
+	switch format {
+	case "p3":
+		return c.WriteP3(w)
+	case "p6":
+		return c.WriteP6(w)
+	case "png":
+		return c.WritePNG(w)
+	default:
+		return fmt.Errorf("unknown image format %q: expected one of %s", format, strings.Join(Formats, ", "))
+	}
+}
+
+// FormatForPath picks the image format from the file extension: png for .png, and p3 for anything else.
+func FormatForPath(path string) string {
+	if strings.EqualFold(filepath.Ext(path), ".png") {
+		return "png"
+	}
+	return "p3"
+}
@@ -0,0 +1,56 @@
+package canvas
+
+import (
+	"bytes"
+	"image/png"
+	"testing"
+)
+
+func TestWritePNG(t *testing.T) {
+	c := NewCanvas(4, 3)
+	c.WritePixel(1, 2, NewColor(1, 0.5, -1))
+
+	var buf bytes.Buffer
+	if err := c.WritePNG(&buf); err != nil {
+		t.Fatalf("WritePNG() error = %v", err)
+	}
+
+	img, err := png.Decode(&buf)
+	if err != nil {
+		t.Fatalf("png.Decode() error = %v", err)
+	}
+	if bounds := img.Bounds(); bounds.Dx() != 4 || bounds.Dy() != 3 {
+		t.Fatalf("image size = %dx%d, want 4x3", bounds.Dx(), bounds.Dy())
+	}
+	r, g, b, a := img.At(1, 2).RGBA()
+	if r>>8 != 255 || g>>8 != 128 || b>>8 != 0 || a>>8 != 255 {
+		t.Errorf("pixel (1, 2) = (%d, %d, %d, %d), want (255, 128, 0, 255)", r>>8, g>>8, b>>8, a>>8)
+	}
+}
+
+func TestWrite(t *testing.T) {
+	c := NewCanvas(2, 2)
+	for _, format := range Formats {
+		if err := c.Write(&bytes.Buffer{}, format); err != nil {
+			t.Errorf("Write(%q) error = %v", format, err)
+		}
+	}
+	if err := c.Write(&bytes.Buffer{}, "gif"); err == nil {
+		t.Errorf("Write(\"gif\") expected an error")
+	}
+}
+
+func TestFormatForPath(t *testing.T) {
+	tests := map[string]string{
+		"out.png":     "png",
+		"OUT.PNG":     "png",
+		"out.ppm":     "p3",
+		"out":         "p3",
+		"dir.png/out": "p3",
+	}
+	for path, expected := range tests {
+		if got := FormatForPath(path); got != expected {
+			t.Errorf("FormatForPath(%q) = %q, want %q", path, got, expected)
+		}
+	}
+}
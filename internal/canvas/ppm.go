@@ -0,0 +1,56 @@
+package canvas
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+)
+
+// PPMMaxValue is the maximum color value written to PPM files.
+const PPMMaxValue = 255
+
+// PPMMaxLineLength is the longest line allowed in a plain-text PPM file.
+const PPMMaxLineLength = 70
+
+// WriteP3 writes the canvas as a plain-text (P3) PPM image.
+// Each row of pixels starts on a new line, and lines are wrapped between values to at most PPMMaxLineLength characters.
+func (c *Canvas) WriteP3(w io.Writer) error {
+	bw := bufio.NewWriter(w)
+	fmt.Fprintf(bw, "P3\n%d %d\n%d\n", c.width, c.height, PPMMaxValue)
+	for y := 0; y < c.height; y++ {
+		lineLength := 0
+		for x := 0; x < c.width; x++ {
+			pixel := c.PixelAt(x, y)
+			for _, component := range []float64{pixel.R(), pixel.G(), pixel.B()} {
+				value := strconv.Itoa(scaleComponent(component, PPMMaxValue))
+				if lineLength > 0 && lineLength+1+len(value) > PPMMaxLineLength {
+					bw.WriteByte('\n')
+					lineLength = 0
+				}
+				if lineLength > 0 {
+					bw.WriteByte(' ')
+					lineLength++
+				}
+				bw.WriteString(value)
+				lineLength += len(value)
+			}
+		}
+		bw.WriteByte('\n')
+	}
+	return bw.Flush()
+}
+
+// WriteP6 writes the canvas as a binary (P6) PPM image.
+func (c *Canvas) WriteP6(w io.Writer) error {
+	bw := bufio.NewWriter(w)
+	fmt.Fprintf(bw, "P6\n%d %d\n%d\n", c.width, c.height, PPMMaxValue)
+	for _, pixel := range c.pixels {
+		bw.Write([]byte{
+			byte(scaleComponent(pixel.R(), PPMMaxValue)),
+			byte(scaleComponent(pixel.G(), PPMMaxValue)),
+			byte(scaleComponent(pixel.B(), PPMMaxValue)),
+		})
+	}
+	return bw.Flush()
+}
@@ -0,0 +1,65 @@
+package canvas
+
+import (
+	"bytes"
+	"testing"
+)
+
+func ppmFixture() *Canvas {
+	c := NewCanvas(5, 3)
+	c.WritePixel(0, 0, NewColor(1.5, 0, 0))
+	c.WritePixel(2, 1, NewColor(0, 0.5, 0))
+	c.WritePixel(4, 2, NewColor(-0.5, 0, 1))
+	return c
+}
+
+func TestWriteP3(t *testing.T) {
+	var buf bytes.Buffer
+	if err := ppmFixture().WriteP3(&buf); err != nil {
+		t.Fatalf("WriteP3() error = %v", err)
+	}
+
+	expected := "P3\n5 3\n255\n" +
+		"255 0 0 0 0 0 0 0 0 0 0 0 0 0 0\n" +
+		"0 0 0 0 0 0 0 128 0 0 0 0 0 0 0\n" +
+		"0 0 0 0 0 0 0 0 0 0 0 0 0 0 255\n"
+	if got := buf.String(); got != expected {
+		t.Errorf("WriteP3() =\n%q\nwant\n%q", got, expected)
+	}
+}
+
+func TestWriteP3WrapsLongLines(t *testing.T) {
+	c := NewCanvas(10, 2)
+	c.Fill(NewColor(1, 0.8, 0.6))
+
+	var buf bytes.Buffer
+	if err := c.WriteP3(&buf); err != nil {
+		t.Fatalf("WriteP3() error = %v", err)
+	}
+
+	expected := "P3\n10 2\n255\n" +
+		"255 204 153 255 204 153 255 204 153 255 204 153 255 204 153 255 204\n" +
+		"153 255 204 153 255 204 153 255 204 153 255 204 153\n" +
+		"255 204 153 255 204 153 255 204 153 255 204 153 255 204 153 255 204\n" +
+		"153 255 204 153 255 204 153 255 204 153 255 204 153\n"
+	if got := buf.String(); got != expected {
+		t.Errorf("WriteP3() =\n%s\nwant\n%s", got, expected)
+	}
+}
+
+func TestWriteP6(t *testing.T) {
+	var buf bytes.Buffer
+	if err := ppmFixture().WriteP6(&buf); err != nil {
+		t.Fatalf("WriteP6() error = %v", err)
+	}
+
+	header := "P6\n5 3\n255\n"
+	got := buf.Bytes()
+	if len(got) != len(header)+5*3*3 || string(got[:len(header)]) != header {
+		t.Fatalf("WriteP6() wrote %d bytes with header %q", len(got), got[:min(len(got), len(header))])
+	}
+	pixels := got[len(header):]
+	if !bytes.Equal(pixels[0:3], []byte{255, 0, 0}) || !bytes.Equal(pixels[(1*5+2)*3:(1*5+2)*3+3], []byte{0, 128, 0}) || !bytes.Equal(pixels[len(pixels)-3:], []byte{0, 0, 255}) {
+		t.Errorf("WriteP6() pixel data = %v", pixels)
+	}
+}
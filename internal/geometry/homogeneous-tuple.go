@@ -222,6 +222,16 @@ func (t HomogeneousTuple) Normalize() HomogeneousTuple {
 	return t.Divide(t.Magnitude())
 }
 
+// Reflect returns the vector reflected around the given normal (t - normal * 2 * t·normal).
+// Both tuples must be vectors; otherwise it returns a NaN tuple.
+func (t HomogeneousTuple) Reflect(normal HomogeneousTuple) HomogeneousTuple {
+	if !(t.IsVector() && normal.IsVector()) {
+		return NaNTuple()
+	}
+
+	return t.Subtract(normal.Multiply(2 * t.DotProduct(normal)))
+}
+
 func (t HomogeneousTuple) Equals(other HomogeneousTuple, epsilon ...float64) bool {
 	eps := epsilonOrDefault(epsilon...)
 
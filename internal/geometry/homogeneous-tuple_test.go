@@ -326,3 +326,34 @@ func TestCrossProduct(t *testing.T) {
 		})
 	}
 }
+
+func TestReflect(t *testing.T) {
+	tests := []struct {
+		name     string
+		v        HomogeneousTuple
+		normal   HomogeneousTuple
+		expected HomogeneousTuple
+	}{
+		{name: "reflecting a vector approaching at 45 degrees", v: NewVector(1, -1, 0), normal: NewVector(0, 1, 0), expected: NewVector(1, 1, 0)},
+		{name: "reflecting a vector off a slanted surface", v: NewVector(0, -1, 0), normal: NewVector(math.Sqrt2/2, math.Sqrt2/2, 0), expected: NewVector(1, 0, 0)},
+		{name: "reflecting a point", v: NewPoint(1, -1, 0), normal: NewVector(0, 1, 0), expected: NaNTuple()},
+		{name: "reflecting around a point", v: NewVector(1, -1, 0), normal: NewPoint(0, 1, 0), expected: NaNTuple()},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := tt.v.Reflect(tt.normal)
+
+			if tt.expected.IsNaN() {
+				if !result.IsNaN() {
+					t.Errorf("Reflect() = %v, want %v", result.String(), tt.expected.String())
+				}
+				return
+			}
+
+			if !result.Equals(tt.expected) {
+				t.Errorf("Reflect() = %v, want %v", result.String(), tt.expected.String())
+			}
+		})
+	}
+}
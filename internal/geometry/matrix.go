@@ -0,0 +1,243 @@
+package geometry
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+)
+
+// ErrNotInvertible is returned when inverting a matrix whose determinant is zero.
+var ErrNotInvertible = errors.New("matrix is not invertible")
+
+// Matrix2 is a 2x2 matrix, used when computing the determinant of a Matrix3.
+type Matrix2 struct {
+	m [2][2]float64
+}
+
+// Matrix3 is a 3x3 matrix, used when computing the cofactors of a Matrix4.
+type Matrix3 struct {
+	m [3][3]float64
+}
+
+// Matrix4 is a 4x4 matrix that transforms HomogeneousTuples.
+type Matrix4 struct {
+	m [4][4]float64
+}
+
+// Creates a 2x2 matrix from its rows.
+func NewMatrix2(rows [2][2]float64) Matrix2 {
+	return Matrix2{m: rows}
+}
+
+// Creates a 3x3 matrix from its rows.
+func NewMatrix3(rows [3][3]float64) Matrix3 {
+	return Matrix3{m: rows}
+}
+
+// Creates a 4x4 matrix from its rows.
+func NewMatrix4(rows [4][4]float64) Matrix4 {
+	return Matrix4{m: rows}
+}
+
+// Identity returns the 4x4 identity matrix.
+func Identity() Matrix4 {
+	return NewMatrix4([4][4]float64{
+		{1, 0, 0, 0},
+		{0, 1, 0, 0},
+		{0, 0, 1, 0},
+		{0, 0, 0, 1},
+	})
+}
+
+func (a Matrix2) At(row, col int) float64 {
+	return a.m[row][col]
+}
+
+func (a Matrix3) At(row, col int) float64 {
+	return a.m[row][col]
+}
+
+func (a Matrix4) At(row, col int) float64 {
+	return a.m[row][col]
+}
+
+func (a Matrix2) Determinant() float64 {
+	return a.m[0][0]*a.m[1][1] - a.m[0][1]*a.m[1][0]
+}
+
+// Submatrix returns the 2x2 matrix left after removing the given row and column.
+func (a Matrix3) Submatrix(row, col int) Matrix2 {
+	var sub Matrix2
+	for r, sr := 0, 0; r < 3; r++ {
+		if r == row {
+			continue
+		}
+		for c, sc := 0, 0; c < 3; c++ {
+			if c == col {
+				continue
+			}
+			sub.m[sr][sc] = a.m[r][c]
+			sc++
+		}
+		sr++
+	}
+	return sub
+}
+
+// Minor is the determinant of the submatrix at (row, col).
+func (a Matrix3) Minor(row, col int) float64 {
+	return a.Submatrix(row, col).Determinant()
+}
+
+// Cofactor is the minor at (row, col), negated when row+col is odd.
+func (a Matrix3) Cofactor(row, col int) float64 {
+	return cofactorSign(row, col) * a.Minor(row, col)
+}
+
+// Determinant is computed by cofactor expansion along the first row.
+func (a Matrix3) Determinant() float64 {
+	det := 0.0
+	for c := 0; c < 3; c++ {
+		det += a.m[0][c] * a.Cofactor(0, c)
+	}
+	return det
+}
+
+// Submatrix returns the 3x3 matrix left after removing the given row and column.
+func (a Matrix4) Submatrix(row, col int) Matrix3 {
+	var sub Matrix3
+	for r, sr := 0, 0; r < 4; r++ {
+		if r == row {
+			continue
+		}
+		for c, sc := 0, 0; c < 4; c++ {
+			if c == col {
+				continue
+			}
+			sub.m[sr][sc] = a.m[r][c]
+			sc++
+		}
+		sr++
+	}
+	return sub
+}
+
+// Minor is the determinant of the submatrix at (row, col).
+func (a Matrix4) Minor(row, col int) float64 {
+	return a.Submatrix(row, col).Determinant()
+}
+
+// Cofactor is the minor at (row, col), negated when row+col is odd.
+func (a Matrix4) Cofactor(row, col int) float64 {
+	return cofactorSign(row, col) * a.Minor(row, col)
+}
+
+// Determinant is computed by cofactor expansion along the first row.
+func (a Matrix4) Determinant() float64 {
+	det := 0.0
+	for c := 0; c < 4; c++ {
+		det += a.m[0][c] * a.Cofactor(0, c)
+	}
+	return det
+}
+
+func cofactorSign(row, col int) float64 {
+	if (row+col)%2 == 1 {
+		return -1
+	}
+	return 1
+}
+
+// Equals compares the matrices element by element using IsNearTo.
+func (a Matrix4) Equals(other Matrix4, epsilon ...float64) bool {
+	eps := epsilonOrDefault(epsilon...)
+	for r := 0; r < 4; r++ {
+		for c := 0; c < 4; c++ {
+			if !IsNearTo(a.m[r][c], other.m[r][c], eps) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// Multiply returns the matrix product a×b.
+// When used as transformations, the product applies b first and then a.
+func (a Matrix4) Multiply(b Matrix4) Matrix4 {
+	var product Matrix4
+	for r := 0; r < 4; r++ {
+		for c := 0; c < 4; c++ {
+			for k := 0; k < 4; k++ {
+				product.m[r][c] += a.m[r][k] * b.m[k][c]
+			}
+		}
+	}
+	return product
+}
+
+// MultiplyTuple returns the tuple transformed by the matrix, treating the tuple as a column vector.
+// Since a vector has w=0, the translation part of the matrix does not affect it.
+func (a Matrix4) MultiplyTuple(t HomogeneousTuple) HomogeneousTuple {
+	row := func(r int) float64 {
+		return a.m[r][0]*t.X() + a.m[r][1]*t.Y() + a.m[r][2]*t.Z() + a.m[r][3]*t.W()
+	}
+	return NewTuple(row(0), row(1), row(2), row(3))
+}
+
+// Transpose returns the matrix with its rows and columns swapped.
+func (a Matrix4) Transpose() Matrix4 {
+	var transposed Matrix4
+	for r := 0; r < 4; r++ {
+		for c := 0; c < 4; c++ {
+			transposed.m[c][r] = a.m[r][c]
+		}
+	}
+	return transposed
+}
+
+// IsInvertible reports whether the determinant of the matrix is non-zero.
+func (a Matrix4) IsInvertible() bool {
+	return a.Determinant() != 0
+}
+
+// Inverse returns the inverse of the matrix, computed from its cofactors and determinant.
+// If the matrix is singular, it returns a NaN matrix and ErrNotInvertible.
+func (a Matrix4) Inverse() (Matrix4, error) {
+	det := a.Determinant()
+	if det == 0 {
+		return nanMatrix4(), ErrNotInvertible
+	}
+
+	var inverse Matrix4
+	for r := 0; r < 4; r++ {
+		for c := 0; c < 4; c++ {
+			// transposing the cofactors as they are stored
+			inverse.m[c][r] = a.Cofactor(r, c) / det
+		}
+	}
+	return inverse, nil
+}
+
+func nanMatrix4() Matrix4 {
+	var m Matrix4
+	for r := 0; r < 4; r++ {
+		for c := 0; c < 4; c++ {
+			m.m[r][c] = math.NaN()
+		}
+	}
+	return m
+}
+
+func (a Matrix4) String() string {
+	var sb strings.Builder
+	sb.WriteString("Matrix4(")
+	for r := 0; r < 4; r++ {
+		if r > 0 {
+			sb.WriteString(", ")
+		}
+		fmt.Fprintf(&sb, "[%f %f %f %f]", a.m[r][0], a.m[r][1], a.m[r][2], a.m[r][3])
+	}
+	sb.WriteString(")")
+	return sb.String()
+}
@@ -0,0 +1,186 @@
+package geometry
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestMatrixConstruction(t *testing.T) {
+	m := NewMatrix4([4][4]float64{
+		{1, 2, 3, 4},
+		{5.5, 6.5, 7.5, 8.5},
+		{9, 10, 11, 12},
+		{13.5, 14.5, 15.5, 16.5},
+	})
+
+	tests := []struct {
+		row, col int
+		expected float64
+	}{
+		{row: 0, col: 0, expected: 1},
+		{row: 0, col: 3, expected: 4},
+		{row: 1, col: 0, expected: 5.5},
+		{row: 1, col: 2, expected: 7.5},
+		{row: 2, col: 2, expected: 11},
+		{row: 3, col: 0, expected: 13.5},
+		{row: 3, col: 2, expected: 15.5},
+	}
+
+	for _, tt := range tests {
+		if got := m.At(tt.row, tt.col); got != tt.expected {
+			t.Errorf("At(%d, %d) = %v, want %v", tt.row, tt.col, got, tt.expected)
+		}
+	}
+}
+
+func TestMatrixEquality(t *testing.T) {
+	a := NewMatrix4([4][4]float64{{1, 2, 3, 4}, {5, 6, 7, 8}, {9, 8, 7, 6}, {5, 4, 3, 2}})
+	b := NewMatrix4([4][4]float64{{1, 2, 3, 4}, {5, 6, 7, 8}, {9, 8, 7, 6}, {5, 4, 3, 2.0000001}})
+	c := NewMatrix4([4][4]float64{{2, 3, 4, 5}, {6, 7, 8, 9}, {8, 7, 6, 5}, {4, 3, 2, 1}})
+
+	if !a.Equals(b) {
+		t.Errorf("Equals() = false for matrices within epsilon")
+	}
+	if a.Equals(c) {
+		t.Errorf("Equals() = true for different matrices")
+	}
+	if a.Equals(b, 1e-9) {
+		t.Errorf("Equals() = true for matrices outside a custom epsilon")
+	}
+}
+
+func TestMatrixMultiplication(t *testing.T) {
+	a := NewMatrix4([4][4]float64{{1, 2, 3, 4}, {5, 6, 7, 8}, {9, 8, 7, 6}, {5, 4, 3, 2}})
+	b := NewMatrix4([4][4]float64{{-2, 1, 2, 3}, {3, 2, 1, -1}, {4, 3, 6, 5}, {1, 2, 7, 8}})
+	expected := NewMatrix4([4][4]float64{{20, 22, 50, 48}, {44, 54, 114, 108}, {40, 58, 110, 102}, {16, 26, 46, 42}})
+
+	if got := a.Multiply(b); !got.Equals(expected) {
+		t.Errorf("Multiply() = %v, want %v", got, expected)
+	}
+	if got := a.Multiply(Identity()); !got.Equals(a) {
+		t.Errorf("Multiply(Identity()) = %v, want %v", got, a)
+	}
+}
+
+func TestMatrixTupleMultiplication(t *testing.T) {
+	a := NewMatrix4([4][4]float64{{1, 2, 3, 4}, {2, 4, 4, 2}, {8, 6, 4, 1}, {0, 0, 0, 1}})
+
+	tests := []struct {
+		name     string
+		matrix   Matrix4
+		tuple    HomogeneousTuple
+		expected HomogeneousTuple
+	}{
+		{name: "tuple", matrix: a, tuple: NewTuple(1, 2, 3, 1), expected: NewTuple(18, 24, 33, 1)},
+		{name: "vector ignores translation", matrix: a, tuple: NewVector(1, 2, 3), expected: NewVector(14, 22, 32)},
+		{name: "identity", matrix: Identity(), tuple: NewTuple(1, 2, 3, 4), expected: NewTuple(1, 2, 3, 4)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.matrix.MultiplyTuple(tt.tuple); !got.Equals(tt.expected) {
+				t.Errorf("MultiplyTuple() = %v, want %v", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestMatrixTranspose(t *testing.T) {
+	a := NewMatrix4([4][4]float64{{0, 9, 3, 0}, {9, 8, 0, 8}, {1, 8, 5, 3}, {0, 0, 5, 8}})
+	expected := NewMatrix4([4][4]float64{{0, 9, 1, 0}, {9, 8, 8, 0}, {3, 0, 5, 5}, {0, 8, 3, 8}})
+
+	if got := a.Transpose(); !got.Equals(expected) {
+		t.Errorf("Transpose() = %v, want %v", got, expected)
+	}
+	if got := Identity().Transpose(); !got.Equals(Identity()) {
+		t.Errorf("Identity().Transpose() = %v, want identity", got)
+	}
+}
+
+func TestSmallMatrices(t *testing.T) {
+	if got := NewMatrix2([2][2]float64{{1, 5}, {-3, 2}}).Determinant(); got != 17 {
+		t.Errorf("Matrix2.Determinant() = %v, want 17", got)
+	}
+
+	a := NewMatrix3([3][3]float64{{3, 5, 0}, {2, -1, -7}, {6, -1, 5}})
+	if got, want := a.Submatrix(0, 2), NewMatrix2([2][2]float64{{2, -1}, {6, -1}}); got != want {
+		t.Errorf("Matrix3.Submatrix(0, 2) = %v, want %v", got, want)
+	}
+	if got := a.Minor(1, 0); got != 25 {
+		t.Errorf("Matrix3.Minor(1, 0) = %v, want 25", got)
+	}
+	if got := a.Cofactor(0, 0); got != -12 {
+		t.Errorf("Matrix3.Cofactor(0, 0) = %v, want -12", got)
+	}
+	if got := a.Cofactor(1, 0); got != -25 {
+		t.Errorf("Matrix3.Cofactor(1, 0) = %v, want -25", got)
+	}
+
+	b := NewMatrix3([3][3]float64{{1, 2, 6}, {-5, 8, -4}, {2, 6, 4}})
+	if got := b.Determinant(); got != -196 {
+		t.Errorf("Matrix3.Determinant() = %v, want -196", got)
+	}
+}
+
+func TestMatrixDeterminant(t *testing.T) {
+	a := NewMatrix4([4][4]float64{{-2, -8, 3, 5}, {-3, 1, 7, 3}, {1, 2, -9, 6}, {-6, 7, 7, -9}})
+
+	tests := []struct {
+		name     string
+		got      float64
+		expected float64
+	}{
+		{name: "cofactor(0, 0)", got: a.Cofactor(0, 0), expected: 690},
+		{name: "cofactor(0, 1)", got: a.Cofactor(0, 1), expected: 447},
+		{name: "cofactor(0, 2)", got: a.Cofactor(0, 2), expected: 210},
+		{name: "cofactor(0, 3)", got: a.Cofactor(0, 3), expected: 51},
+		{name: "determinant", got: a.Determinant(), expected: -4071},
+	}
+
+	for _, tt := range tests {
+		if tt.got != tt.expected {
+			t.Errorf("%s = %v, want %v", tt.name, tt.got, tt.expected)
+		}
+	}
+}
+
+func TestMatrixInverse(t *testing.T) {
+	a := NewMatrix4([4][4]float64{{-5, 2, 6, -8}, {1, -5, 1, 8}, {7, 7, -6, -7}, {1, -3, 7, 4}})
+	expected := NewMatrix4([4][4]float64{
+		{0.21805, 0.45113, 0.24060, -0.04511},
+		{-0.80827, -1.45677, -0.44361, 0.52068},
+		{-0.07895, -0.22368, -0.05263, 0.19737},
+		{-0.52256, -0.81391, -0.30075, 0.30639},
+	})
+
+	inverse, err := a.Inverse()
+	if err != nil {
+		t.Fatalf("Inverse() error = %v", err)
+	}
+	if !inverse.Equals(expected, 1e-5) {
+		t.Errorf("Inverse() = %v, want %v", inverse, expected)
+	}
+
+	b := NewMatrix4([4][4]float64{{3, -9, 7, 3}, {3, -8, 2, -9}, {-4, 4, 4, 1}, {-6, 5, -1, 1}})
+	c := NewMatrix4([4][4]float64{{8, 2, 2, 2}, {3, -1, 7, 0}, {7, 0, 5, 4}, {6, -2, 0, 5}})
+	cInverse, _ := c.Inverse()
+	if got := b.Multiply(c).Multiply(cInverse); !got.Equals(b) {
+		t.Errorf("A×B×inverse(B) = %v, want %v", got, b)
+	}
+}
+
+func TestMatrixNotInvertible(t *testing.T) {
+	a := NewMatrix4([4][4]float64{{-4, 2, -2, -3}, {9, 6, 2, 6}, {0, -5, 1, -5}, {0, 0, 0, 0}})
+
+	if a.IsInvertible() {
+		t.Errorf("IsInvertible() = true, want false")
+	}
+	inverse, err := a.Inverse()
+	if !errors.Is(err, ErrNotInvertible) {
+		t.Errorf("Inverse() error = %v, want %v", err, ErrNotInvertible)
+	}
+	if !math.IsNaN(inverse.At(0, 0)) {
+		t.Errorf("Inverse() = %v, want a NaN matrix", inverse)
+	}
+}
@@ -0,0 +1,157 @@
+package geometry
+
+import (
+	"fmt"
+	"math"
+)
+
+// Quaternion represents a rotation in 3D space as w + xi + yj + zk.
+// Only unit quaternions represent rotations; q and -q represent the same rotation.
+type Quaternion struct {
+	w, x, y, z float64
+}
+
+func NewQuaternion(w, x, y, z float64) Quaternion {
+	return Quaternion{w: w, x: x, y: y, z: z}
+}
+
+// IdentityQuaternion is the rotation that leaves every vector unchanged.
+func IdentityQuaternion() Quaternion {
+	return NewQuaternion(1, 0, 0, 0)
+}
+
+// QuaternionFromAxisAngle creates the rotation by angle radians around the axis vector, turning in the same
+// direction as RotationX, RotationY and RotationZ do around their axes (see RotationX).
+// If the axis is not a non-zero vector, it returns a NaN quaternion.
+func QuaternionFromAxisAngle(axis HomogeneousTuple, angle float64) Quaternion {
+	if !axis.IsVector() || axis.Magnitude() == 0 {
+		return NewQuaternion(math.NaN(), math.NaN(), math.NaN(), math.NaN())
+	}
+
+	unit := axis.Normalize()
+	sin := math.Sin(angle / 2)
+	return NewQuaternion(math.Cos(angle/2), unit.X()*sin, unit.Y()*sin, unit.Z()*sin)
+}
+
+// QuaternionFromMatrix extracts the rotation from the upper-left 3x3 part of a rotation matrix.
+// It uses the largest of the diagonal terms to stay numerically stable.
+func QuaternionFromMatrix(m Matrix4) Quaternion {
+	trace := m.At(0, 0) + m.At(1, 1) + m.At(2, 2)
+	var q Quaternion
+	switch {
+	case trace > 0:
+		s := 2 * math.Sqrt(trace+1)
+		q = NewQuaternion(s/4, (m.At(2, 1)-m.At(1, 2))/s, (m.At(0, 2)-m.At(2, 0))/s, (m.At(1, 0)-m.At(0, 1))/s)
+	case m.At(0, 0) > m.At(1, 1) && m.At(0, 0) > m.At(2, 2):
+		s := 2 * math.Sqrt(1+m.At(0, 0)-m.At(1, 1)-m.At(2, 2))
+		q = NewQuaternion((m.At(2, 1)-m.At(1, 2))/s, s/4, (m.At(0, 1)+m.At(1, 0))/s, (m.At(0, 2)+m.At(2, 0))/s)
+	case m.At(1, 1) > m.At(2, 2):
+		s := 2 * math.Sqrt(1+m.At(1, 1)-m.At(0, 0)-m.At(2, 2))
+		q = NewQuaternion((m.At(0, 2)-m.At(2, 0))/s, (m.At(0, 1)+m.At(1, 0))/s, s/4, (m.At(1, 2)+m.At(2, 1))/s)
+	default:
+		s := 2 * math.Sqrt(1+m.At(2, 2)-m.At(0, 0)-m.At(1, 1))
+		q = NewQuaternion((m.At(1, 0)-m.At(0, 1))/s, (m.At(0, 2)+m.At(2, 0))/s, (m.At(1, 2)+m.At(2, 1))/s, s/4)
+	}
+	return q.Normalize()
+}
+
+func (q Quaternion) W() float64 {
+	return q.w
+}
+
+func (q Quaternion) X() float64 {
+	return q.x
+}
+
+func (q Quaternion) Y() float64 {
+	return q.y
+}
+
+func (q Quaternion) Z() float64 {
+	return q.z
+}
+
+// Multiply returns the Hamilton product q×r.
+// As rotations, the product applies r first and then q, matching the order of Matrix4.Multiply.
+func (q Quaternion) Multiply(r Quaternion) Quaternion {
+	return NewQuaternion(
+		q.w*r.w-q.x*r.x-q.y*r.y-q.z*r.z,
+		q.w*r.x+q.x*r.w+q.y*r.z-q.z*r.y,
+		q.w*r.y-q.x*r.z+q.y*r.w+q.z*r.x,
+		q.w*r.z+q.x*r.y-q.y*r.x+q.z*r.w,
+	)
+}
+
+// Conjugate negates the vector part; for a unit quaternion it is the inverse rotation.
+func (q Quaternion) Conjugate() Quaternion {
+	return NewQuaternion(q.w, -q.x, -q.y, -q.z)
+}
+
+func (q Quaternion) Dot(r Quaternion) float64 {
+	return q.w*r.w + q.x*r.x + q.y*r.y + q.z*r.z
+}
+
+func (q Quaternion) Magnitude() float64 {
+	return math.Sqrt(q.Dot(q))
+}
+
+// Normalize returns the unit quaternion in the same direction.
+// For a zero quaternion, it returns a NaN quaternion.
+func (q Quaternion) Normalize() Quaternion {
+	m := q.Magnitude()
+	if m == 0 {
+		return NewQuaternion(math.NaN(), math.NaN(), math.NaN(), math.NaN())
+	}
+	return NewQuaternion(q.w/m, q.x/m, q.y/m, q.z/m)
+}
+
+// Rotate applies the rotation to the x, y and z components of the tuple, keeping its w value.
+// Like a rotation matrix, it rotates points about the origin.
+func (q Quaternion) Rotate(t HomogeneousTuple) HomogeneousTuple {
+	rotated := q.Multiply(NewQuaternion(0, t.X(), t.Y(), t.Z())).Multiply(q.Conjugate())
+	return NewTuple(rotated.x, rotated.y, rotated.z, t.W())
+}
+
+// ToMatrix returns the rotation matrix of the unit quaternion.
+func (q Quaternion) ToMatrix() Matrix4 {
+	w, x, y, z := q.w, q.x, q.y, q.z
+	return NewMatrix4([4][4]float64{
+		{1 - 2*(y*y+z*z), 2 * (x*y - w*z), 2 * (x*z + w*y), 0},
+		{2 * (x*y + w*z), 1 - 2*(x*x+z*z), 2 * (y*z - w*x), 0},
+		{2 * (x*z - w*y), 2 * (y*z + w*x), 1 - 2*(x*x+y*y), 0},
+		{0, 0, 0, 1},
+	})
+}
+
+// Slerp interpolates along the shortest great arc between the rotations q (t=0) and r (t=1) at a constant angular speed.
+// When the rotations are nearly identical, it falls back to a normalized linear interpolation.
+func (q Quaternion) Slerp(r Quaternion, t float64) Quaternion {
+	cos := q.Dot(r)
+	if cos < 0 {
+		// q and -q are the same rotation; take the shorter way around
+		r, cos = NewQuaternion(-r.w, -r.x, -r.y, -r.z), -cos
+	}
+
+	a, b := 1-t, t
+	if cos < 1-EPSILON {
+		theta := math.Acos(cos)
+		sin := math.Sin(theta)
+		a, b = math.Sin((1-t)*theta)/sin, math.Sin(t*theta)/sin
+	}
+
+	return NewQuaternion(a*q.w+b*r.w, a*q.x+b*r.x, a*q.y+b*r.y, a*q.z+b*r.z).Normalize()
+}
+
+// Equals compares the components using IsNearTo; it does not treat q and -q as equal.
+func (q Quaternion) Equals(r Quaternion, epsilon ...float64) bool {
+	eps := epsilonOrDefault(epsilon...)
+
+	return IsNearTo(q.w, r.w, eps) &&
+		IsNearTo(q.x, r.x, eps) &&
+		IsNearTo(q.y, r.y, eps) &&
+		IsNearTo(q.z, r.z, eps)
+}
+
+func (q Quaternion) String() string {
+	return fmt.Sprintf("Quaternion(%f, %f, %f, %f)", q.w, q.x, q.y, q.z)
+}
@@ -0,0 +1,104 @@
+package geometry
+
+import (
+	"math"
+	"testing"
+)
+
+func TestQuaternionMatchesRotationMatrices(t *testing.T) {
+	angle := math.Pi / 3
+	tests := []struct {
+		name     string
+		axis     HomogeneousTuple
+		expected Matrix4
+	}{
+		{name: "x axis", axis: NewVector(1, 0, 0), expected: RotationX(angle)},
+		{name: "y axis", axis: NewVector(0, 2, 0), expected: RotationY(angle)},
+		{name: "z axis", axis: NewVector(0, 0, 1), expected: RotationZ(angle)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			q := QuaternionFromAxisAngle(tt.axis, angle)
+			if got := q.ToMatrix(); !got.Equals(tt.expected) {
+				t.Errorf("ToMatrix() = %v, want %v", got, tt.expected)
+			}
+			v := NewVector(1, 2, 3)
+			if got, want := q.Rotate(v), tt.expected.MultiplyTuple(v); !got.Equals(want) {
+				t.Errorf("Rotate() = %v, want %v", got, want)
+			}
+		})
+	}
+}
+
+func TestQuaternionFromAxisAngleRejectsPoints(t *testing.T) {
+	q := QuaternionFromAxisAngle(NewPoint(1, 0, 0), math.Pi)
+	if !math.IsNaN(q.W()) {
+		t.Errorf("QuaternionFromAxisAngle() = %v, want NaN", q)
+	}
+}
+
+func TestQuaternionComposition(t *testing.T) {
+	rx := QuaternionFromAxisAngle(NewVector(1, 0, 0), math.Pi/2)
+	rz := QuaternionFromAxisAngle(NewVector(0, 0, 1), math.Pi/4)
+
+	composed := rz.Multiply(rx)
+	expected := RotationZ(math.Pi / 4).Multiply(RotationX(math.Pi / 2))
+	if got := composed.ToMatrix(); !got.Equals(expected) {
+		t.Errorf("Multiply().ToMatrix() = %v, want %v", got, expected)
+	}
+
+	if got := rx.Multiply(rx.Conjugate()); !got.Equals(IdentityQuaternion()) {
+		t.Errorf("q×conjugate(q) = %v, want identity", got)
+	}
+}
+
+func TestQuaternionFromMatrix(t *testing.T) {
+	tests := []struct {
+		name string
+		q    Quaternion
+	}{
+		{name: "identity", q: IdentityQuaternion()},
+		{name: "small rotation", q: QuaternionFromAxisAngle(NewVector(1, 2, 3), 0.3)},
+		{name: "half turn around x", q: QuaternionFromAxisAngle(NewVector(1, 0, 0), math.Pi)},
+		{name: "half turn around y", q: QuaternionFromAxisAngle(NewVector(0, 1, 0), math.Pi)},
+		{name: "half turn around z", q: QuaternionFromAxisAngle(NewVector(0, 0, 1), math.Pi)},
+		{name: "large rotation", q: QuaternionFromAxisAngle(NewVector(-1, 1, 2), 2.9)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := QuaternionFromMatrix(tt.q.ToMatrix())
+			if !got.Equals(tt.q) && !got.Equals(NewQuaternion(-tt.q.W(), -tt.q.X(), -tt.q.Y(), -tt.q.Z())) {
+				t.Errorf("QuaternionFromMatrix() = %v, want ±%v", got, tt.q)
+			}
+		})
+	}
+}
+
+func TestQuaternionSlerp(t *testing.T) {
+	axis := NewVector(0, 1, 0)
+	from := QuaternionFromAxisAngle(axis, 0)
+	to := QuaternionFromAxisAngle(axis, math.Pi/2)
+
+	tests := []struct {
+		name     string
+		to       Quaternion
+		t        float64
+		expected Quaternion
+	}{
+		{name: "start", to: to, t: 0, expected: from},
+		{name: "end", to: to, t: 1, expected: to},
+		{name: "constant angular speed", to: to, t: 0.25, expected: QuaternionFromAxisAngle(axis, math.Pi/8)},
+		{name: "takes the shorter arc", to: NewQuaternion(-to.W(), -to.X(), -to.Y(), -to.Z()), t: 0.5, expected: QuaternionFromAxisAngle(axis, math.Pi/4)},
+		{name: "nearly identical rotations", to: QuaternionFromAxisAngle(axis, 1e-9), t: 0.5, expected: QuaternionFromAxisAngle(axis, 5e-10)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := from.Slerp(tt.to, tt.t); !got.Equals(tt.expected) {
+				t.Errorf("Slerp() = %v, want %v", got, tt.expected)
+			}
+		})
+	}
+}
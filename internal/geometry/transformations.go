@@ -0,0 +1,111 @@
+package geometry
+
+import (
+	"math"
+)
+
+// Translation moves points by (x, y, z); vectors are unaffected since they have w=0.
+func Translation(x, y, z float64) Matrix4 {
+	return NewMatrix4([4][4]float64{
+		{1, 0, 0, x},
+		{0, 1, 0, y},
+		{0, 0, 1, z},
+		{0, 0, 0, 1},
+	})
+}
+
+// Scaling multiplies each component of points and vectors by the matching factor.
+// A negative factor reflects across the corresponding axis.
+func Scaling(x, y, z float64) Matrix4 {
+	return NewMatrix4([4][4]float64{
+		{x, 0, 0, 0},
+		{0, y, 0, 0},
+		{0, 0, z, 0},
+		{0, 0, 0, 1},
+	})
+}
+
+// RotationX rotates around the x axis by r radians, clockwise when looking toward the origin along the axis (left-handed).
+func RotationX(r float64) Matrix4 {
+	cos, sin := math.Cos(r), math.Sin(r)
+	return NewMatrix4([4][4]float64{
+		{1, 0, 0, 0},
+		{0, cos, -sin, 0},
+		{0, sin, cos, 0},
+		{0, 0, 0, 1},
+	})
+}
+
+// RotationY rotates around the y axis by r radians.
+func RotationY(r float64) Matrix4 {
+	cos, sin := math.Cos(r), math.Sin(r)
+	return NewMatrix4([4][4]float64{
+		{cos, 0, sin, 0},
+		{0, 1, 0, 0},
+		{-sin, 0, cos, 0},
+		{0, 0, 0, 1},
+	})
+}
+
+// RotationZ rotates around the z axis by r radians.
+func RotationZ(r float64) Matrix4 {
+	cos, sin := math.Cos(r), math.Sin(r)
+	return NewMatrix4([4][4]float64{
+		{cos, -sin, 0, 0},
+		{sin, cos, 0, 0},
+		{0, 0, 1, 0},
+		{0, 0, 0, 1},
+	})
+}
+
+// Shearing moves each component in proportion to the other two; xy is the amount x moves in proportion to y, and so on.
+func Shearing(xy, xz, yx, yz, zx, zy float64) Matrix4 {
+	return NewMatrix4([4][4]float64{
+		{1, xy, xz, 0},
+		{yx, 1, yz, 0},
+		{zx, zy, 1, 0},
+		{0, 0, 0, 1},
+	})
+}
+
+// ViewTransform orients the world relative to an eye at the point from, looking at the point to,
+// with the vector up pointing (approximately) upward.
+func ViewTransform(from, to, up HomogeneousTuple) Matrix4 {
+	forward := ToPoint(to).Subtract(ToPoint(from)).Normalize()
+	left := forward.CrossProduct(ToVector(up).Normalize())
+	trueUp := left.CrossProduct(forward)
+	orientation := NewMatrix4([4][4]float64{
+		{left.X(), left.Y(), left.Z(), 0},
+		{trueUp.X(), trueUp.Y(), trueUp.Z(), 0},
+		{-forward.X(), -forward.Y(), -forward.Z(), 0},
+		{0, 0, 0, 1},
+	})
+	return orientation.Multiply(Translation(-from.X(), -from.Y(), -from.Z()))
+}
+
+// The chaining methods below apply a transformation after the ones already in the matrix, so that
+// Identity().RotateX(a).Scale(x, y, z).Translate(x, y, z) rotates first, then scales, then translates.
+
+func (a Matrix4) Translate(x, y, z float64) Matrix4 {
+	return Translation(x, y, z).Multiply(a)
+}
+
+func (a Matrix4) Scale(x, y, z float64) Matrix4 {
+	return Scaling(x, y, z).Multiply(a)
+}
+
+func (a Matrix4) RotateX(r float64) Matrix4 {
+	return RotationX(r).Multiply(a)
+}
+
+func (a Matrix4) RotateY(r float64) Matrix4 {
+	return RotationY(r).Multiply(a)
+}
+
+func (a Matrix4) RotateZ(r float64) Matrix4 {
+	return RotationZ(r).Multiply(a)
+}
+
+func (a Matrix4) Shear(xy, xz, yx, yz, zx, zy float64) Matrix4 {
+	return Shearing(xy, xz, yx, yz, zx, zy).Multiply(a)
+}
@@ -0,0 +1,95 @@
+package geometry
+
+import (
+	"math"
+	"testing"
+)
+
+func TestTransformations(t *testing.T) {
+	halfQuarter, fullQuarter := math.Pi/4, math.Pi/2
+	inverseTranslation, _ := Translation(5, -3, 2).Inverse()
+	inverseScaling, _ := Scaling(2, 3, 4).Inverse()
+	inverseRotationX, _ := RotationX(halfQuarter).Inverse()
+
+	tests := []struct {
+		name      string
+		transform Matrix4
+		tuple     HomogeneousTuple
+		expected  HomogeneousTuple
+	}{
+		{name: "translate a point", transform: Translation(5, -3, 2), tuple: NewPoint(-3, 4, 5), expected: NewPoint(2, 1, 7)},
+		{name: "inverse translation", transform: inverseTranslation, tuple: NewPoint(-3, 4, 5), expected: NewPoint(-8, 7, 3)},
+		{name: "translation does not affect vectors", transform: Translation(5, -3, 2), tuple: NewVector(-3, 4, 5), expected: NewVector(-3, 4, 5)},
+		{name: "scale a point", transform: Scaling(2, 3, 4), tuple: NewPoint(-4, 6, 8), expected: NewPoint(-8, 18, 32)},
+		{name: "scale a vector", transform: Scaling(2, 3, 4), tuple: NewVector(-4, 6, 8), expected: NewVector(-8, 18, 32)},
+		{name: "inverse scaling", transform: inverseScaling, tuple: NewVector(-4, 6, 8), expected: NewVector(-2, 2, 2)},
+		{name: "reflection", transform: Scaling(-1, 1, 1), tuple: NewPoint(2, 3, 4), expected: NewPoint(-2, 3, 4)},
+		{name: "rotate x by half quarter", transform: RotationX(halfQuarter), tuple: NewPoint(0, 1, 0), expected: NewPoint(0, math.Sqrt2/2, math.Sqrt2/2)},
+		{name: "rotate x by full quarter", transform: RotationX(fullQuarter), tuple: NewPoint(0, 1, 0), expected: NewPoint(0, 0, 1)},
+		{name: "inverse x rotation", transform: inverseRotationX, tuple: NewPoint(0, 1, 0), expected: NewPoint(0, math.Sqrt2/2, -math.Sqrt2/2)},
+		{name: "rotate y by half quarter", transform: RotationY(halfQuarter), tuple: NewPoint(0, 0, 1), expected: NewPoint(math.Sqrt2/2, 0, math.Sqrt2/2)},
+		{name: "rotate y by full quarter", transform: RotationY(fullQuarter), tuple: NewPoint(0, 0, 1), expected: NewPoint(1, 0, 0)},
+		{name: "rotate z by half quarter", transform: RotationZ(halfQuarter), tuple: NewPoint(0, 1, 0), expected: NewPoint(-math.Sqrt2/2, math.Sqrt2/2, 0)},
+		{name: "rotate z by full quarter", transform: RotationZ(fullQuarter), tuple: NewPoint(0, 1, 0), expected: NewPoint(-1, 0, 0)},
+		{name: "shear x in proportion to y", transform: Shearing(1, 0, 0, 0, 0, 0), tuple: NewPoint(2, 3, 4), expected: NewPoint(5, 3, 4)},
+		{name: "shear x in proportion to z", transform: Shearing(0, 1, 0, 0, 0, 0), tuple: NewPoint(2, 3, 4), expected: NewPoint(6, 3, 4)},
+		{name: "shear y in proportion to x", transform: Shearing(0, 0, 1, 0, 0, 0), tuple: NewPoint(2, 3, 4), expected: NewPoint(2, 5, 4)},
+		{name: "shear y in proportion to z", transform: Shearing(0, 0, 0, 1, 0, 0), tuple: NewPoint(2, 3, 4), expected: NewPoint(2, 7, 4)},
+		{name: "shear z in proportion to x", transform: Shearing(0, 0, 0, 0, 1, 0), tuple: NewPoint(2, 3, 4), expected: NewPoint(2, 3, 6)},
+		{name: "shear z in proportion to y", transform: Shearing(0, 0, 0, 0, 0, 1), tuple: NewPoint(2, 3, 4), expected: NewPoint(2, 3, 7)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.transform.MultiplyTuple(tt.tuple); !got.Equals(tt.expected) {
+				t.Errorf("MultiplyTuple() = %v, want %v", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestChainedTransformations(t *testing.T) {
+	p := NewPoint(1, 0, 1)
+	chained := RotationX(math.Pi / 2).Multiply(Identity())
+	chained = Scaling(5, 5, 5).Multiply(chained)
+	chained = Translation(10, 5, 7).Multiply(chained)
+	expected := NewPoint(15, 0, 7)
+
+	if got := chained.MultiplyTuple(p); !got.Equals(expected) {
+		t.Errorf("chained product = %v, want %v", got, expected)
+	}
+
+	fluent := Identity().RotateX(math.Pi/2).Scale(5, 5, 5).Translate(10, 5, 7)
+	if !fluent.Equals(chained) {
+		t.Errorf("fluent chain = %v, want %v", fluent, chained)
+	}
+	if got := fluent.MultiplyTuple(p); !got.Equals(expected) {
+		t.Errorf("fluent chain applied = %v, want %v", got, expected)
+	}
+}
+
+func TestViewTransform(t *testing.T) {
+	tests := []struct {
+		name         string
+		from, to, up HomogeneousTuple
+		expected     Matrix4
+	}{
+		{name: "default orientation", from: NewPoint(0, 0, 0), to: NewPoint(0, 0, -1), up: NewVector(0, 1, 0), expected: Identity()},
+		{name: "looking in the positive z direction", from: NewPoint(0, 0, 0), to: NewPoint(0, 0, 1), up: NewVector(0, 1, 0), expected: Scaling(-1, 1, -1)},
+		{name: "moves the world", from: NewPoint(0, 0, 8), to: NewPoint(0, 0, 0), up: NewVector(0, 1, 0), expected: Translation(0, 0, -8)},
+		{name: "arbitrary", from: NewPoint(1, 3, 2), to: NewPoint(4, -2, 8), up: NewVector(1, 1, 0), expected: NewMatrix4([4][4]float64{
+			{-0.50709, 0.50709, 0.67612, -2.36643},
+			{0.76772, 0.60609, 0.12122, -2.82843},
+			{-0.35857, 0.59761, -0.71714, 0.00000},
+			{0.00000, 0.00000, 0.00000, 1.00000},
+		})},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ViewTransform(tt.from, tt.to, tt.up); !got.Equals(tt.expected, 1e-5) {
+				t.Errorf("ViewTransform() = %v, want %v", got, tt.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,251 @@
+package projectile
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+
+	"github.com/seanpk/go-for-rays/internal/geometry"
+)
+
+// ErrOutOfRange is returned (wrapped) when no launch can reach the target.
+var ErrOutOfRange = errors.New("target is out of range")
+
+// AimSolution is a launch that lands on the target; angles are in radians.
+type AimSolution struct {
+	Elevation  float64
+	Azimuth    float64
+	Speed      float64
+	Velocity   geometry.HomogeneousTuple
+	FlightTime float64 // time until the projectile descends through the target
+	Miss       float64 // horizontal distance between the impact and the target
+}
+
+type AimOptions struct {
+	Simulation SimulationOptions
+	Tolerance  float64 // acceptable miss distance (default: 1e-3)
+	MaxSpeed   float64 // upper bound when solving for speed (default: 1e4)
+}
+
+func resolveAimOptions(options ...AimOptions) AimOptions {
+	var opts AimOptions
+	if len(options) > 0 {
+		opts = options[0]
+	}
+	if !(opts.Tolerance > 0) {
+		opts.Tolerance = 1e-3
+	}
+	if !(opts.MaxSpeed > 0) {
+		opts.MaxSpeed = 1e4
+	}
+	return opts
+}
+
+// aimer evaluates launches from a fixed point against a fixed target.
+type aimer struct {
+	env      Environment
+	launch   geometry.HomogeneousTuple
+	target   geometry.HomogeneousTuple
+	bearing  float64 // azimuth pointing horizontally at the target
+	distance float64 // horizontal distance to the target
+	opts     AimOptions
+}
+
+func newAimer(env Environment, launchPoint, target geometry.HomogeneousTuple, opts AimOptions) (aimer, error) {
+	if !launchPoint.IsPoint() || !target.IsPoint() {
+		return aimer{}, fmt.Errorf("launch point and target must be points")
+	}
+	offset := target.Subtract(launchPoint)
+	return aimer{
+		env:      env,
+		launch:   launchPoint,
+		target:   target,
+		bearing:  math.Atan2(offset.Y(), offset.X()),
+		distance: math.Hypot(offset.X(), offset.Y()),
+		opts:     opts,
+	}, nil
+}
+
+// impact simulates a launch and returns where and when it descends through the height of the target.
+// It returns false if the projectile never comes down through that height.
+func (a aimer) impact(speed, elevation, azimuth float64) (geometry.HomogeneousTuple, float64, bool) {
+	launch := Projectile{Position: a.launch, Velocity: LaunchVelocity(speed, elevation, azimuth)}
+	trajectory := Simulate(a.env, launch, a.opts.Simulation)
+	if position, time, ok := descentCrossing(trajectory.Samples, a.target.Z()); ok {
+		return position, time, true
+	}
+
+	// a contact is located on the open side of the surface, so a projectile coming to rest
+	// at the height of the target may stop just short of descending through it
+	last := trajectory.Samples[len(trajectory.Samples)-1]
+	if trajectory.Stop != StopMaxTicks && len(trajectory.Samples) > 1 && math.Abs(last.Position.Z()-a.target.Z()) <= a.opts.Tolerance {
+		return last.Position, last.Time, true
+	}
+	return geometry.NaNTuple(), math.NaN(), false
+}
+
+// descentCrossing finds where the samples first descend through height z, interpolating between ticks.
+func descentCrossing(samples []Sample, z float64) (geometry.HomogeneousTuple, float64, bool) {
+	for i := 1; i < len(samples); i++ {
+		prev, cur := samples[i-1], samples[i]
+		if prev.Position.Z() > z && cur.Position.Z() <= z {
+			fraction := (prev.Position.Z() - z) / (prev.Position.Z() - cur.Position.Z())
+			position := prev.Position.Add(cur.Position.Subtract(prev.Position).Multiply(fraction))
+			return position, prev.Time + fraction*(cur.Time-prev.Time), true
+		}
+	}
+	return geometry.NaNTuple(), math.NaN(), false
+}
+
+// alongTrack is the signed distance by which a launch overshoots (positive) or falls short of (negative) the target,
+// measured along the horizontal line from the launch point to the target.
+// A launch that never comes down through the target's height counts as falling short by the full distance.
+func (a aimer) alongTrack(speed, elevation, azimuth float64) float64 {
+	position, _, ok := a.impact(speed, elevation, azimuth)
+	if !ok {
+		return -a.distance - 1
+	}
+	offset := position.Subtract(a.launch)
+	return offset.X()*math.Cos(a.bearing) + offset.Y()*math.Sin(a.bearing) - a.distance
+}
+
+// miss is the horizontal vector from the target to the impact point.
+func (a aimer) miss(speed, elevation, azimuth float64) (float64, float64, bool) {
+	position, _, ok := a.impact(speed, elevation, azimuth)
+	if !ok {
+		return math.NaN(), math.NaN(), false
+	}
+	return position.X() - a.target.X(), position.Y() - a.target.Y(), true
+}
+
+// refine adjusts the free parameter (elevation or speed) together with the azimuth using Newton's method
+// on the horizontal miss vector, which corrects for cross winds that push the projectile off the bearing.
+func (a aimer) refine(param, azimuth float64, launch func(param, azimuth float64) (float64, float64, float64)) (float64, float64) {
+	missAt := func(p, az float64) (float64, float64, bool) {
+		return a.miss(launch(p, az))
+	}
+
+	const h = 1e-6
+	for range 20 {
+		dx, dy, ok := missAt(param, azimuth)
+		if !ok || math.Hypot(dx, dy) <= a.opts.Tolerance {
+			break
+		}
+		hp := h * math.Max(1, math.Abs(param))
+		dxp, dyp, okp := missAt(param+hp, azimuth)
+		dxa, dya, oka := missAt(param, azimuth+h)
+		if !okp || !oka {
+			break
+		}
+		j11, j21 := (dxp-dx)/hp, (dyp-dy)/hp
+		j12, j22 := (dxa-dx)/h, (dya-dy)/h
+		det := j11*j22 - j12*j21
+		if det == 0 {
+			break
+		}
+		param -= (j22*dx - j12*dy) / det
+		azimuth -= (j11*dy - j21*dx) / det
+	}
+	return param, azimuth
+}
+
+// solution evaluates a launch and packages it as an AimSolution.
+func (a aimer) solution(speed, elevation, azimuth float64) (AimSolution, bool) {
+	position, flightTime, ok := a.impact(speed, elevation, azimuth)
+	if !ok {
+		return AimSolution{}, false
+	}
+	miss := math.Hypot(position.X()-a.target.X(), position.Y()-a.target.Y())
+	return AimSolution{
+		Elevation:  elevation,
+		Azimuth:    math.Remainder(azimuth, 2*math.Pi),
+		Speed:      speed,
+		Velocity:   LaunchVelocity(speed, elevation, azimuth),
+		FlightTime: flightTime,
+		Miss:       miss,
+	}, miss <= a.opts.Tolerance
+}
+
+// AimAtSpeed finds the elevations (and azimuths) at which a launch with the given speed lands on the target.
+// It scans the elevation from straight down to straight up for changes between falling short and overshooting,
+// so it usually reports both the low and the high arc, ordered by increasing elevation.
+func AimAtSpeed(env Environment, launchPoint, target geometry.HomogeneousTuple, speed float64, options ...AimOptions) ([]AimSolution, error) {
+	a, err := newAimer(env, launchPoint, target, resolveAimOptions(options...))
+	if err != nil {
+		return nil, err
+	}
+	if !(speed > 0) {
+		return nil, fmt.Errorf("speed must be positive")
+	}
+
+	const steps = 180
+	elevationAt := func(i int) float64 { return -math.Pi/2 + math.Pi*float64(i)/steps }
+	bisect := func(low, high float64) float64 {
+		fLow := a.alongTrack(speed, low, a.bearing)
+		for range 60 {
+			mid := (low + high) / 2
+			if fMid := a.alongTrack(speed, mid, a.bearing); (fMid < 0) == (fLow < 0) {
+				low, fLow = mid, fMid
+			} else {
+				high = mid
+			}
+		}
+		return (low + high) / 2
+	}
+	launch := func(elevation, azimuth float64) (float64, float64, float64) { return speed, elevation, azimuth }
+
+	var solutions []AimSolution
+	best := math.Inf(-1)
+	prev := a.alongTrack(speed, elevationAt(1), a.bearing)
+	for i := 2; i < steps; i++ {
+		cur := a.alongTrack(speed, elevationAt(i), a.bearing)
+		best = math.Max(best, cur)
+		if (prev < 0) != (cur < 0) {
+			elevation, azimuth := a.refine(bisect(elevationAt(i-1), elevationAt(i)), a.bearing, launch)
+			if s, ok := a.solution(speed, elevation, azimuth); ok {
+				solutions = append(solutions, s)
+			}
+		}
+		prev = cur
+	}
+
+	if len(solutions) == 0 {
+		return nil, fmt.Errorf("%w: at speed %g the best launch lands %.3f short of the target", ErrOutOfRange, speed, -best)
+	}
+	sort.Slice(solutions, func(i, j int) bool { return solutions[i].Elevation < solutions[j].Elevation })
+	return solutions, nil
+}
+
+// AimAtElevation finds the launch speed (and azimuth) at which a launch with the given elevation lands on the target.
+func AimAtElevation(env Environment, launchPoint, target geometry.HomogeneousTuple, elevation float64, options ...AimOptions) (AimSolution, error) {
+	a, err := newAimer(env, launchPoint, target, resolveAimOptions(options...))
+	if err != nil {
+		return AimSolution{}, err
+	}
+
+	// grow the upper bound until the launch overshoots, then bisect
+	low, high := 0.0, 1.0
+	for a.alongTrack(high, elevation, a.bearing) < 0 {
+		low, high = high, high*2
+		if high > a.opts.MaxSpeed {
+			return AimSolution{}, fmt.Errorf("%w: no launch at elevation %g° below speed %g reaches the target", ErrOutOfRange, elevation*180/math.Pi, a.opts.MaxSpeed)
+		}
+	}
+	for range 60 {
+		mid := (low + high) / 2
+		if a.alongTrack(mid, elevation, a.bearing) < 0 {
+			low = mid
+		} else {
+			high = mid
+		}
+	}
+
+	launch := func(speed, azimuth float64) (float64, float64, float64) { return speed, elevation, azimuth }
+	speed, azimuth := a.refine((low+high)/2, a.bearing, launch)
+	s, ok := a.solution(speed, elevation, azimuth)
+	if !ok {
+		return AimSolution{}, fmt.Errorf("%w: no launch at elevation %g° lands on the target", ErrOutOfRange, elevation*180/math.Pi)
+	}
+	return s, nil
+}
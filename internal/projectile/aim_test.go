@@ -0,0 +1,101 @@
+package projectile
+
+import (
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/seanpk/go-for-rays/internal/geometry"
+)
+
+func aimOptions() AimOptions {
+	return AimOptions{Simulation: SimulationOptions{Integrator: IntegratorFunc(RK4Step), TimeStep: 0.01}}
+}
+
+func TestAimAtSpeedInVacuum(t *testing.T) {
+	env := Environment{Gravity: geometry.NewVector(0, 0, -9.81), Wind: geometry.NewVector(0, 0, 0)}
+	launch, target := geometry.NewPoint(0, 0, 0), geometry.NewPoint(0, 30, 0)
+
+	solutions, err := AimAtSpeed(env, launch, target, 20, aimOptions())
+	if err != nil {
+		t.Fatalf("AimAtSpeed() error = %v", err)
+	}
+	if len(solutions) != 2 {
+		t.Fatalf("AimAtSpeed() found %d solutions, want 2", len(solutions))
+	}
+
+	// in a vacuum, sin(2*elevation) = g*d/v^2
+	low := math.Asin(9.81*30/400) / 2
+	for i, expected := range []float64{low, math.Pi/2 - low} {
+		if got := solutions[i].Elevation; !geometry.IsNearTo(got, expected, 1e-3) {
+			t.Errorf("solution %d elevation = %v, want %v", i, got, expected)
+		}
+		if got := solutions[i].Azimuth; !geometry.IsNearTo(got, math.Pi/2, 1e-3) {
+			t.Errorf("solution %d azimuth = %v, want %v", i, got, math.Pi/2)
+		}
+	}
+}
+
+func TestAimAtSpeedOutOfRange(t *testing.T) {
+	env := Environment{Gravity: geometry.NewVector(0, 0, -9.81), Wind: geometry.NewVector(0, 0, 0)}
+	_, err := AimAtSpeed(env, geometry.NewPoint(0, 0, 0), geometry.NewPoint(100, 0, 0), 20, aimOptions())
+	if !errors.Is(err, ErrOutOfRange) {
+		t.Errorf("AimAtSpeed() error = %v, want %v", err, ErrOutOfRange)
+	}
+}
+
+func TestAimBelowZeroOnLoweredGround(t *testing.T) {
+	env := Environment{Gravity: geometry.NewVector(0, 0, -9.81), Wind: geometry.NewVector(0, 0, 0)}
+	launch, target := geometry.NewPoint(0, 0, 0), geometry.NewPoint(20, 0, -5)
+
+	// the default ground at z=0 stops the projectile before it can descend to the target
+	if _, err := AimAtSpeed(env, launch, target, 20, aimOptions()); !errors.Is(err, ErrOutOfRange) {
+		t.Errorf("AimAtSpeed() error = %v, want %v", err, ErrOutOfRange)
+	}
+
+	opts := aimOptions()
+	opts.Simulation.Obstacles = []Obstacle{{Name: "ground", Surface: NewPlane(geometry.NewPoint(0, 0, -5), geometry.NewVector(0, 0, 1)), Ground: true}}
+	solutions, err := AimAtSpeed(env, launch, target, 20, opts)
+	if err != nil {
+		t.Fatalf("AimAtSpeed() error = %v", err)
+	}
+	if len(solutions) == 0 {
+		t.Fatalf("AimAtSpeed() found no solutions")
+	}
+	for i, s := range solutions {
+		if s.Miss > 1e-3 {
+			t.Errorf("solution %d misses by %v", i, s.Miss)
+		}
+	}
+}
+
+func TestAimWithCrossWindAndDrag(t *testing.T) {
+	env := Environment{
+		Gravity:    geometry.NewVector(0, 0, -9.81),
+		Wind:       geometry.NewVector(0, 5, 0),
+		AirDensity: 1.225,
+		Body:       Body{Mass: 0.45, DragCoefficient: 0.25, Area: 0.038},
+	}
+	launch, target := geometry.NewPoint(0, 0, 1), geometry.NewPoint(25, 0, 2)
+
+	solutions, err := AimAtSpeed(env, launch, target, 25, aimOptions())
+	if err != nil {
+		t.Fatalf("AimAtSpeed() error = %v", err)
+	}
+	for _, s := range solutions {
+		if s.Miss > 1e-3 {
+			t.Errorf("solution at elevation %v misses by %v", s.Elevation, s.Miss)
+		}
+		if s.Azimuth >= 0 {
+			t.Errorf("solution at elevation %v has azimuth %v, want it to aim into the wind", s.Elevation, s.Azimuth)
+		}
+	}
+
+	s, err := AimAtElevation(env, launch, target, math.Pi/6, aimOptions())
+	if err != nil {
+		t.Fatalf("AimAtElevation() error = %v", err)
+	}
+	if s.Miss > 1e-3 || !geometry.IsNearTo(s.Elevation, math.Pi/6) {
+		t.Errorf("AimAtElevation() = %+v, want a hit at elevation %v", s, math.Pi/6)
+	}
+}
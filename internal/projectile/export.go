@@ -0,0 +1,192 @@
+package projectile
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+
+	"github.com/seanpk/go-for-rays/internal/geometry"
+)
+
+// LaunchParameters records everything needed to reproduce a simulation run.
+// It is written as the header (CSV) or metadata record (JSON, NDJSON) of an exported trajectory.
+type LaunchParameters struct {
+	LaunchPoint     [3]float64 `json:"launch_point"`
+	Velocity        [3]float64 `json:"velocity"`
+	Wind            [3]float64 `json:"wind"`
+	Gravity         [3]float64 `json:"gravity"`
+	Mass            float64    `json:"mass"`
+	DragCoefficient float64    `json:"drag_coefficient"`
+	Area            float64    `json:"area"`
+	AirDensity      float64    `json:"air_density"`
+	Integrator      string     `json:"integrator"`
+	TimeStep        float64    `json:"dt"`
+	MaxTicks        int        `json:"max_ticks"`
+}
+
+// NewLaunchParameters collects the launch parameters of a simulation run.
+func NewLaunchParameters(env Environment, launch Projectile, integrator string, options SimulationOptions) LaunchParameters {
+	opts := resolveSimulationOptions(options)
+	return LaunchParameters{
+		LaunchPoint:     components(launch.Position),
+		Velocity:        components(launch.Velocity),
+		Wind:            components(env.Wind),
+		Gravity:         components(env.Gravity),
+		Mass:            env.Body.Mass,
+		DragCoefficient: env.Body.DragCoefficient,
+		Area:            env.Body.Area,
+		AirDensity:      env.AirDensity,
+		Integrator:      integrator,
+		TimeStep:        opts.TimeStep,
+		MaxTicks:        opts.MaxTicks,
+	}
+}
+
+func components(t geometry.HomogeneousTuple) [3]float64 {
+	return [3]float64{t.X(), t.Y(), t.Z()}
+}
+
+// sampleRecord is the flat, exported representation of a Sample.
+type sampleRecord struct {
+	Tick int     `json:"tick"`
+	Time float64 `json:"time"`
+	X    float64 `json:"x"`
+	Y    float64 `json:"y"`
+	Z    float64 `json:"z"`
+	VX   float64 `json:"vx"`
+	VY   float64 `json:"vy"`
+	VZ   float64 `json:"vz"`
+}
+
+func newSampleRecord(s Sample) sampleRecord {
+	return sampleRecord{
+		Tick: s.Tick,
+		Time: s.Time,
+		X:    s.Position.X(),
+		Y:    s.Position.Y(),
+		Z:    s.Position.Z(),
+		VX:   s.Velocity.X(),
+		VY:   s.Velocity.Y(),
+		VZ:   s.Velocity.Z(),
+	}
+}
+
+// summaryRecord is the exported representation of a Summary.
+type summaryRecord struct {
+	Ticks      int        `json:"ticks"`
+	FlightTime float64    `json:"flight_time"`
+	Apex       [3]float64 `json:"apex"`
+	Landing    [3]float64 `json:"landing"`
+	Landed     bool       `json:"landed"`
+	Stop       StopReason `json:"stop"`
+	Obstacle   string     `json:"obstacle,omitempty"`
+	Bounces    int        `json:"bounces"`
+}
+
+func newSummaryRecord(s Summary) summaryRecord {
+	return summaryRecord{
+		Ticks:      s.Ticks,
+		FlightTime: s.FlightTime,
+		Apex:       components(s.Apex),
+		Landing:    components(s.Landing),
+		Landed:     s.Landed,
+		Stop:       s.Stop,
+		Obstacle:   s.Obstacle,
+		Bounces:    s.Bounces,
+	}
+}
+
+// ExportFormats lists the structured formats supported by Export.
+var ExportFormats = []string{"csv", "json", "ndjson"}
+
+// Export writes the trajectory in the named structured format.
+func Export(w io.Writer, format string, params LaunchParameters, trajectory Trajectory) error {
+	switch format {
+	case "csv":
+		return WriteCSV(w, params, trajectory)
+	case "json":
+		return WriteJSON(w, params, trajectory)
+	case "ndjson":
+		return WriteNDJSON(w, params, trajectory)
+	default:
+		return fmt.Errorf("unknown export format %q", format)
+	}
+}
+
+// WriteCSV writes the trajectory as CSV, one row per sample.
+// The launch parameters precede the column header as '#'-prefixed comment lines holding JSON.
+func WriteCSV(w io.Writer, params LaunchParameters, trajectory Trajectory) error {
+	metadata, err := json.Marshal(params)
+	if err != nil {
+		return err
+	}
+	if _, err := fmt.Fprintf(w, "# %s\n", metadata); err != nil {
+		return err
+	}
+
+	cw := csv.NewWriter(w)
+	if err := cw.Write([]string{"tick", "time", "x", "y", "z", "vx", "vy", "vz"}); err != nil {
+		return err
+	}
+	for _, s := range trajectory.Samples {
+		r := newSampleRecord(s)
+		row := []string{strconv.Itoa(r.Tick)}
+		for _, v := range []float64{r.Time, r.X, r.Y, r.Z, r.VX, r.VY, r.VZ} {
+			row = append(row, strconv.FormatFloat(v, 'g', -1, 64))
+		}
+		if err := cw.Write(row); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// WriteJSON writes the trajectory as a single JSON document holding the parameters, samples and summary.
+func WriteJSON(w io.Writer, params LaunchParameters, trajectory Trajectory) error {
+	samples := make([]sampleRecord, 0, len(trajectory.Samples))
+	for _, s := range trajectory.Samples {
+		samples = append(samples, newSampleRecord(s))
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(struct {
+		Parameters LaunchParameters `json:"parameters"`
+		Samples    []sampleRecord   `json:"samples"`
+		Summary    summaryRecord    `json:"summary"`
+	}{
+		Parameters: params,
+		Samples:    samples,
+		Summary:    newSummaryRecord(trajectory.Summarize()),
+	})
+}
+
+// WriteNDJSON writes the trajectory as newline-delimited JSON.
+// The first record holds the parameters, followed by one record per sample and a final summary record;
+// each record is tagged by its "type" field.
+func WriteNDJSON(w io.Writer, params LaunchParameters, trajectory Trajectory) error {
+	encoder := json.NewEncoder(w)
+	if err := encoder.Encode(struct {
+		Type string `json:"type"`
+		LaunchParameters
+	}{Type: "parameters", LaunchParameters: params}); err != nil {
+		return err
+	}
+
+	for _, s := range trajectory.Samples {
+		if err := encoder.Encode(struct {
+			Type string `json:"type"`
+			sampleRecord
+		}{Type: "sample", sampleRecord: newSampleRecord(s)}); err != nil {
+			return err
+		}
+	}
+
+	return encoder.Encode(struct {
+		Type string `json:"type"`
+		summaryRecord
+	}{Type: "summary", summaryRecord: newSummaryRecord(trajectory.Summarize())})
+}
@@ -0,0 +1,85 @@
+package projectile
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/seanpk/go-for-rays/internal/geometry"
+)
+
+func exportFixture() (LaunchParameters, Trajectory) {
+	env := Environment{Gravity: geometry.NewVector(0, 0, -1), Wind: geometry.NewVector(0, 0, 0)}
+	launch := Projectile{Position: geometry.NewPoint(0, 0, 0), Velocity: geometry.NewVector(1, 0, 1)}
+	return NewLaunchParameters(env, launch, "euler", SimulationOptions{}), Simulate(env, launch)
+}
+
+func TestExport(t *testing.T) {
+	params, trajectory := exportFixture()
+
+	tests := []struct {
+		format string
+		lines  []string
+	}{
+		{format: "csv", lines: []string{
+			`# {"launch_point":[0,0,0],"velocity":[1,0,1],"wind":[0,0,0],"gravity":[0,0,-1],"mass":0,"drag_coefficient":0,"area":0,"air_density":0,"integrator":"euler","dt":1,"max_ticks":10000}`,
+			"tick,time,x,y,z,vx,vy,vz",
+			"0,0,0,0,0,1,0,1",
+			"1,1,1,0,1,1,0,0",
+			"2,2,2,0,1,1,0,-1",
+			"3,3,3,0,0,1,0,-2",
+		}},
+		{format: "ndjson", lines: []string{
+			`{"type":"parameters","launch_point":[0,0,0],"velocity":[1,0,1],"wind":[0,0,0],"gravity":[0,0,-1],"mass":0,"drag_coefficient":0,"area":0,"air_density":0,"integrator":"euler","dt":1,"max_ticks":10000}`,
+			`{"type":"sample","tick":0,"time":0,"x":0,"y":0,"z":0,"vx":1,"vy":0,"vz":1}`,
+			`{"type":"sample","tick":1,"time":1,"x":1,"y":0,"z":1,"vx":1,"vy":0,"vz":0}`,
+			`{"type":"sample","tick":2,"time":2,"x":2,"y":0,"z":1,"vx":1,"vy":0,"vz":-1}`,
+			`{"type":"sample","tick":3,"time":3,"x":3,"y":0,"z":0,"vx":1,"vy":0,"vz":-2}`,
+			`{"type":"summary","ticks":3,"flight_time":3,"apex":[1,0,1],"landing":[3,0,0],"landed":true,"stop":"landed","obstacle":"ground","bounces":0}`,
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.format, func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := Export(&buf, tt.format, params, trajectory); err != nil {
+				t.Fatalf("Export() error = %v", err)
+			}
+			if got, want := buf.String(), strings.Join(tt.lines, "\n")+"\n"; got != want {
+				t.Errorf("Export() =\n%s\nwant\n%s", got, want)
+			}
+		})
+	}
+}
+
+func TestExportJSON(t *testing.T) {
+	params, trajectory := exportFixture()
+
+	var buf bytes.Buffer
+	if err := Export(&buf, "json", params, trajectory); err != nil {
+		t.Fatalf("Export() error = %v", err)
+	}
+
+	var document struct {
+		Parameters LaunchParameters `json:"parameters"`
+		Samples    []sampleRecord   `json:"samples"`
+		Summary    summaryRecord    `json:"summary"`
+	}
+	if err := json.Unmarshal(buf.Bytes(), &document); err != nil {
+		t.Fatalf("Export() produced invalid JSON: %v", err)
+	}
+	if document.Parameters != params {
+		t.Errorf("parameters = %+v, want %+v", document.Parameters, params)
+	}
+	if len(document.Samples) != 4 || !document.Summary.Landed {
+		t.Errorf("samples = %d, landed = %v, want 4 samples and landed", len(document.Samples), document.Summary.Landed)
+	}
+}
+
+func TestExportUnknownFormat(t *testing.T) {
+	params, trajectory := exportFixture()
+	if err := Export(&bytes.Buffer{}, "xml", params, trajectory); err == nil {
+		t.Errorf("Export() expected an error for an unknown format")
+	}
+}
@@ -0,0 +1,103 @@
+package projectile
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/seanpk/go-for-rays/internal/geometry"
+)
+
+// Integrator advances a projectile by a time step dt in the given environment.
+type Integrator interface {
+	Step(env Environment, p Projectile, dt float64) Projectile
+}
+
+// IntegratorFunc adapts an ordinary function to the Integrator interface.
+type IntegratorFunc func(env Environment, p Projectile, dt float64) Projectile
+
+func (f IntegratorFunc) Step(env Environment, p Projectile, dt float64) Projectile {
+	return f(env, p, dt)
+}
+
+// The integrators available by name, e.g. for selection on the command line.
+var integrators = map[string]Integrator{
+	"euler":               IntegratorFunc(EulerStep),
+	"semi-implicit-euler": IntegratorFunc(SemiImplicitEulerStep),
+	"verlet":              IntegratorFunc(VerletStep),
+	"rk4":                 IntegratorFunc(RK4Step),
+}
+
+// DefaultIntegrator is the name of the integrator used when none is specified.
+const DefaultIntegrator = "euler"
+
+// IntegratorByName returns the integrator registered under name, or an error listing the valid names.
+func IntegratorByName(name string) (Integrator, error) {
+	integrator, ok := integrators[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown integrator %q: expected one of %s", name, strings.Join(IntegratorNames(), ", "))
+	}
+	return integrator, nil
+}
+
+// IntegratorNames returns the sorted names of the available integrators.
+func IntegratorNames() []string {
+	names := make([]string, 0, len(integrators))
+	for name := range integrators {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// EulerStep is the explicit (forward) Euler method.
+// The position moves by the current velocity, then the velocity is changed by the current acceleration.
+func EulerStep(env Environment, p Projectile, dt float64) Projectile {
+	acceleration := env.Acceleration(p)
+	return Projectile{
+		Position: p.Position.Add(p.Velocity.Multiply(dt)),
+		Velocity: p.Velocity.Add(acceleration.Multiply(dt)),
+	}
+}
+
+// SemiImplicitEulerStep is the symplectic Euler method.
+// The velocity is updated first, and the position moves by the updated velocity.
+func SemiImplicitEulerStep(env Environment, p Projectile, dt float64) Projectile {
+	velocity := p.Velocity.Add(env.Acceleration(p).Multiply(dt))
+	return Projectile{
+		Position: p.Position.Add(velocity.Multiply(dt)),
+		Velocity: velocity,
+	}
+}
+
+// VerletStep is the velocity Verlet method.
+// Since the acceleration may depend on velocity, the new acceleration is evaluated at a velocity predicted by an Euler step.
+func VerletStep(env Environment, p Projectile, dt float64) Projectile {
+	acceleration := env.Acceleration(p)
+	position := p.Position.Add(p.Velocity.Multiply(dt)).Add(acceleration.Multiply(0.5 * dt * dt))
+	predicted := Projectile{Position: position, Velocity: p.Velocity.Add(acceleration.Multiply(dt))}
+	next := env.Acceleration(predicted)
+	return Projectile{
+		Position: position,
+		Velocity: p.Velocity.Add(acceleration.Add(next).Multiply(0.5 * dt)),
+	}
+}
+
+// RK4Step is the classic fourth-order Runge-Kutta method applied to the state (position, velocity).
+func RK4Step(env Environment, p Projectile, dt float64) Projectile {
+	derive := func(state Projectile) (geometry.HomogeneousTuple, geometry.HomogeneousTuple) {
+		return state.Velocity, env.Acceleration(state)
+	}
+	advance := func(dx, dv geometry.HomogeneousTuple, h float64) Projectile {
+		return Projectile{Position: p.Position.Add(dx.Multiply(h)), Velocity: p.Velocity.Add(dv.Multiply(h))}
+	}
+
+	dx1, dv1 := derive(p)
+	dx2, dv2 := derive(advance(dx1, dv1, dt/2))
+	dx3, dv3 := derive(advance(dx2, dv2, dt/2))
+	dx4, dv4 := derive(advance(dx3, dv3, dt))
+
+	dx := dx1.Add(dx2.Multiply(2)).Add(dx3.Multiply(2)).Add(dx4)
+	dv := dv1.Add(dv2.Multiply(2)).Add(dv3.Multiply(2)).Add(dv4)
+	return advance(dx, dv, dt/6)
+}
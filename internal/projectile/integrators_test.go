@@ -0,0 +1,56 @@
+package projectile
+
+import (
+	"testing"
+
+	"github.com/seanpk/go-for-rays/internal/geometry"
+)
+
+func TestEulerStep(t *testing.T) {
+	env := Environment{Gravity: geometry.NewVector(0, 0, -1), Wind: geometry.NewVector(0.5, 0, 0)}
+	p := Projectile{Position: geometry.NewPoint(0, 0, 1), Velocity: geometry.NewVector(1, 0, 2)}
+
+	next := EulerStep(env, p, 1)
+	if want := geometry.NewPoint(1, 0, 3); !next.Position.Equals(want) {
+		t.Errorf("EulerStep() position = %v, want %v", next.Position, want)
+	}
+	if want := geometry.NewVector(1.5, 0, 1); !next.Velocity.Equals(want) {
+		t.Errorf("EulerStep() velocity = %v, want %v", next.Velocity, want)
+	}
+}
+
+func TestIntegratorByName(t *testing.T) {
+	for _, name := range IntegratorNames() {
+		if _, err := IntegratorByName(name); err != nil {
+			t.Errorf("IntegratorByName(%q) error = %v", name, err)
+		}
+	}
+	if _, err := IntegratorByName("leapfrog"); err == nil {
+		t.Errorf("IntegratorByName(\"leapfrog\") expected an error")
+	}
+}
+
+func TestIntegratorAccuracyInVacuum(t *testing.T) {
+	env := Environment{Gravity: geometry.NewVector(0, 0, -9.81), Wind: geometry.NewVector(0, 0, 0)}
+	launch := Projectile{Position: geometry.NewPoint(0, 0, 0), Velocity: geometry.NewVector(10, 0, 20)}
+
+	tests := []struct {
+		name     string
+		maxError float64
+	}{
+		{name: "euler", maxError: 2.5},
+		{name: "semi-implicit-euler", maxError: 2.5},
+		{name: "verlet", maxError: 1e-9},
+		{name: "rk4", maxError: 1e-9},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			integrator, _ := IntegratorByName(tt.name)
+			trajectory := Simulate(env, launch, SimulationOptions{Integrator: integrator, TimeStep: 0.1})
+			if got := VacuumError(env.Gravity, trajectory); got > tt.maxError {
+				t.Errorf("VacuumError() = %v, want <= %v", got, tt.maxError)
+			}
+		})
+	}
+}
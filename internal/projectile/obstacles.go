@@ -0,0 +1,107 @@
+package projectile
+
+import (
+	"math"
+
+	"github.com/seanpk/go-for-rays/internal/geometry"
+)
+
+// Surface is the geometry of an obstacle that a projectile can collide with.
+type Surface interface {
+	// Crossing reports where the straight segment from one point to another first enters the surface,
+	// as a fraction of the segment in [0, 1], along with the unit surface normal facing the incoming projectile.
+	Crossing(from, to geometry.HomogeneousTuple) (float64, geometry.HomogeneousTuple, bool)
+}
+
+// Obstacle is a surface in the environment that stops or deflects the projectile.
+type Obstacle struct {
+	Name        string
+	Surface     Surface
+	Restitution float64 // fraction of the normal speed kept when bouncing; zero stops the projectile
+	Ground      bool    // hitting the ground counts as landing rather than hitting an obstacle
+}
+
+// Plane is an infinite plane through a point; the side its normal points to is open space.
+type Plane struct {
+	point  geometry.HomogeneousTuple
+	normal geometry.HomogeneousTuple
+}
+
+func NewPlane(point, normal geometry.HomogeneousTuple) Plane {
+	return Plane{point: geometry.ToPoint(point), normal: geometry.ToVector(normal).Normalize()}
+}
+
+// signedDistance is positive on the open side of the plane and negative behind it.
+func (p Plane) signedDistance(point geometry.HomogeneousTuple) float64 {
+	return point.Subtract(p.point).DotProduct(p.normal)
+}
+
+// Crossing reports where the segment passes from the open side of the plane to behind it.
+// A segment starting on the plane counts as crossing only when it moves behind the plane.
+func (p Plane) Crossing(from, to geometry.HomogeneousTuple) (float64, geometry.HomogeneousTuple, bool) {
+	dFrom, dTo := p.signedDistance(from), p.signedDistance(to)
+	if !(dFrom >= 0 && dTo <= 0 && dFrom > dTo) {
+		return 0, geometry.NaNTuple(), false
+	}
+	return dFrom / (dFrom - dTo), p.normal, true
+}
+
+// Box is a solid axis-aligned box spanning two opposite corners.
+type Box struct {
+	min, max geometry.HomogeneousTuple
+}
+
+func NewBox(corner, opposite geometry.HomogeneousTuple) Box {
+	return Box{
+		min: geometry.NewPoint(math.Min(corner.X(), opposite.X()), math.Min(corner.Y(), opposite.Y()), math.Min(corner.Z(), opposite.Z())),
+		max: geometry.NewPoint(math.Max(corner.X(), opposite.X()), math.Max(corner.Y(), opposite.Y()), math.Max(corner.Z(), opposite.Z())),
+	}
+}
+
+// Crossing uses the slab method to find where the segment enters the box from outside.
+// A segment that starts inside the box does not cross it.
+func (b Box) Crossing(from, to geometry.HomogeneousTuple) (float64, geometry.HomogeneousTuple, bool) {
+	direction := to.Subtract(from)
+	origins := [3]float64{from.X(), from.Y(), from.Z()}
+	directions := [3]float64{direction.X(), direction.Y(), direction.Z()}
+	mins := [3]float64{b.min.X(), b.min.Y(), b.min.Z()}
+	maxs := [3]float64{b.max.X(), b.max.Y(), b.max.Z()}
+
+	enter, exit, axis := math.Inf(-1), math.Inf(1), -1
+	for i := range 3 {
+		if directions[i] == 0 {
+			if origins[i] < mins[i] || origins[i] > maxs[i] {
+				return 0, geometry.NaNTuple(), false
+			}
+			continue
+		}
+		t0, t1 := (mins[i]-origins[i])/directions[i], (maxs[i]-origins[i])/directions[i]
+		if t0 > t1 {
+			t0, t1 = t1, t0
+		}
+		if t0 > enter {
+			enter, axis = t0, i
+		}
+		exit = math.Min(exit, t1)
+	}
+
+	if axis < 0 || enter > exit || enter < 0 || enter > 1 {
+		return 0, geometry.NaNTuple(), false
+	}
+
+	normal := [3]float64{}
+	normal[axis] = -math.Copysign(1, directions[axis])
+	return enter, geometry.NewVector(normal[0], normal[1], normal[2]), true
+}
+
+// DefaultGround is the flat ground at z=0 used when no ground obstacle is given.
+var DefaultGround = Obstacle{
+	Name:    "ground",
+	Surface: NewPlane(geometry.NewPoint(0, 0, 0), geometry.NewVector(0, 0, 1)),
+	Ground:  true,
+}
+
+// Bounce reflects the velocity off a surface with the given normal, keeping the given fraction of the normal speed.
+func Bounce(velocity, normal geometry.HomogeneousTuple, restitution float64) geometry.HomogeneousTuple {
+	return velocity.Subtract(normal.Multiply((1 + restitution) * velocity.DotProduct(normal)))
+}
@@ -0,0 +1,93 @@
+package projectile
+
+import (
+	"testing"
+
+	"github.com/seanpk/go-for-rays/internal/geometry"
+)
+
+func TestCrossing(t *testing.T) {
+	tests := []struct {
+		name     string
+		surface  Surface
+		from, to geometry.HomogeneousTuple
+		fraction float64
+		normal   geometry.HomogeneousTuple
+		ok       bool
+	}{
+		{name: "plane crossed from above", surface: NewPlane(geometry.NewPoint(0, 0, 0), geometry.NewVector(0, 0, 2)), from: geometry.NewPoint(0, 0, 1), to: geometry.NewPoint(1, 0, -3), fraction: 0.25, normal: geometry.NewVector(0, 0, 1), ok: true},
+		{name: "plane left from its surface", surface: NewPlane(geometry.NewPoint(0, 0, 0), geometry.NewVector(0, 0, 1)), from: geometry.NewPoint(0, 0, 0), to: geometry.NewPoint(1, 0, 1), ok: false},
+		{name: "plane approached from behind", surface: NewPlane(geometry.NewPoint(0, 0, 0), geometry.NewVector(0, 0, 1)), from: geometry.NewPoint(0, 0, -2), to: geometry.NewPoint(1, 0, -1), ok: false},
+		{name: "sloped plane", surface: NewPlane(geometry.NewPoint(0, 0, 0), geometry.NewVector(-1, 0, 1)), from: geometry.NewPoint(2, 0, 4), to: geometry.NewPoint(2, 0, 0), fraction: 0.5, normal: geometry.NewVector(-1, 0, 1).Normalize(), ok: true},
+		{name: "box entered through its side", surface: NewBox(geometry.NewPoint(2, -1, 0), geometry.NewPoint(3, 1, 5)), from: geometry.NewPoint(0, 0, 1), to: geometry.NewPoint(4, 0, 1), fraction: 0.5, normal: geometry.NewVector(-1, 0, 0), ok: true},
+		{name: "box entered through its top", surface: NewBox(geometry.NewPoint(3, 1, 5), geometry.NewPoint(2, -1, 0)), from: geometry.NewPoint(2.5, 0, 7), to: geometry.NewPoint(2.5, 0, 3), fraction: 0.5, normal: geometry.NewVector(0, 0, 1), ok: true},
+		{name: "box missed", surface: NewBox(geometry.NewPoint(2, -1, 0), geometry.NewPoint(3, 1, 5)), from: geometry.NewPoint(0, 0, 6), to: geometry.NewPoint(4, 0, 6), ok: false},
+		{name: "box not reached", surface: NewBox(geometry.NewPoint(2, -1, 0), geometry.NewPoint(3, 1, 5)), from: geometry.NewPoint(0, 0, 1), to: geometry.NewPoint(1, 0, 1), ok: false},
+		{name: "box left from inside", surface: NewBox(geometry.NewPoint(2, -1, 0), geometry.NewPoint(3, 1, 5)), from: geometry.NewPoint(2.5, 0, 1), to: geometry.NewPoint(4, 0, 1), ok: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fraction, normal, ok := tt.surface.Crossing(tt.from, tt.to)
+			if ok != tt.ok {
+				t.Fatalf("Crossing() ok = %v, want %v", ok, tt.ok)
+			}
+			if !ok {
+				return
+			}
+			if !geometry.IsNearTo(fraction, tt.fraction) {
+				t.Errorf("Crossing() fraction = %v, want %v", fraction, tt.fraction)
+			}
+			if !normal.Equals(tt.normal) {
+				t.Errorf("Crossing() normal = %v, want %v", normal, tt.normal)
+			}
+		})
+	}
+}
+
+func TestBounce(t *testing.T) {
+	got := Bounce(geometry.NewVector(1, 0, -2), geometry.NewVector(0, 0, 1), 0.5)
+	if want := geometry.NewVector(1, 0, 1); !got.Equals(want) {
+		t.Errorf("Bounce() = %v, want %v", got, want)
+	}
+}
+
+func TestSimulateWithObstacles(t *testing.T) {
+	env := Environment{Gravity: geometry.NewVector(0, 0, -9.81), Wind: geometry.NewVector(0, 0, 0)}
+	launch := Projectile{Position: geometry.NewPoint(0, 0, 0), Velocity: geometry.NewVector(5, 0, 10)}
+	wall := Obstacle{Name: "wall", Surface: NewBox(geometry.NewPoint(5, -1, 0), geometry.NewPoint(6, 1, 20))}
+	bouncyGround := DefaultGround
+	bouncyGround.Restitution = 0.5
+
+	tests := []struct {
+		name      string
+		obstacles []Obstacle
+		stop      StopReason
+		obstacle  string
+		bounces   int
+		landingX  float64
+	}{
+		{name: "lands on the default ground", stop: StopLanded, obstacle: "ground", landingX: 100.0 / 9.81},
+		{name: "hits a wall", obstacles: []Obstacle{wall}, stop: StopHitObstacle, obstacle: "wall", landingX: 5},
+		{name: "bounces until it comes to rest", obstacles: []Obstacle{bouncyGround}, stop: StopLanded, obstacle: "ground", bounces: 6},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			options := SimulationOptions{Integrator: IntegratorFunc(RK4Step), TimeStep: 0.01, Obstacles: tt.obstacles}
+			summary := Simulate(env, launch, options).Summarize()
+			if summary.Stop != tt.stop || summary.Obstacle != tt.obstacle {
+				t.Errorf("stopped with %q on %q, want %q on %q", summary.Stop, summary.Obstacle, tt.stop, tt.obstacle)
+			}
+			if summary.Bounces != tt.bounces {
+				t.Errorf("Bounces = %v, want %v", summary.Bounces, tt.bounces)
+			}
+			if tt.landingX != 0 && !geometry.IsNearTo(summary.Landing.X(), tt.landingX, 1e-6) {
+				t.Errorf("Landing = %v, want x=%v", summary.Landing, tt.landingX)
+			}
+			if summary.Landing.Z() < 0 {
+				t.Errorf("Landing = %v, want it on or above the ground", summary.Landing)
+			}
+		})
+	}
+}
@@ -0,0 +1,105 @@
+package projectile
+
+import (
+	"math"
+
+	"github.com/seanpk/go-for-rays/internal/canvas"
+)
+
+var (
+	plotBackground = canvas.NewColor(0.05, 0.05, 0.1)
+	plotAxes       = canvas.NewColor(0.5, 0.5, 0.5)
+	plotPath       = canvas.NewColor(0.6, 0.3, 0.2)
+	plotTick       = canvas.NewColor(1, 0.8, 0.2)
+)
+
+// plotMargin is the number of pixels kept free around the plotted area; it shrinks on canvases too small for it.
+const plotMargin = 10
+
+// Plot renders the x/z side view of the trajectory onto a new canvas.
+// The view is scaled uniformly to fit the canvas and always includes the origin, so both axes are drawn.
+// Each tick position is marked, and consecutive positions are joined by a line.
+func Plot(trajectory Trajectory, width, height int) *canvas.Canvas {
+	c := canvas.NewCanvas(width, height)
+	c.Fill(plotBackground)
+	if len(trajectory.Samples) == 0 {
+		return c
+	}
+
+	minX, maxX, minZ, maxZ := 0.0, 0.0, 0.0, 0.0
+	for _, s := range trajectory.Samples {
+		minX, maxX = math.Min(minX, s.Position.X()), math.Max(maxX, s.Position.X())
+		minZ, maxZ = math.Min(minZ, s.Position.Z()), math.Max(maxZ, s.Position.Z())
+	}
+
+	// a margin of half the canvas or more would leave no room, or negative room, for the trajectory
+	margin := min(plotMargin, (min(width, height)-1)/2)
+	spanX, spanZ := math.Max(maxX-minX, 1e-9), math.Max(maxZ-minZ, 1e-9)
+	scale := math.Min(float64(width-2*margin)/spanX, float64(height-2*margin)/spanZ)
+	toPixel := func(x, z float64) (int, int) {
+		return margin + int(math.Round((x-minX)*scale)),
+			height - 1 - margin - int(math.Round((z-minZ)*scale))
+	}
+
+	originX, originY := toPixel(0, 0)
+	drawLine(c, 0, originY, width-1, originY, plotAxes)
+	drawLine(c, originX, 0, originX, height-1, plotAxes)
+
+	prevX, prevY := toPixel(trajectory.Samples[0].Position.X(), trajectory.Samples[0].Position.Z())
+	for _, s := range trajectory.Samples[1:] {
+		x, y := toPixel(s.Position.X(), s.Position.Z())
+		drawLine(c, prevX, prevY, x, y, plotPath)
+		prevX, prevY = x, y
+	}
+
+	for _, s := range trajectory.Samples {
+		x, y := toPixel(s.Position.X(), s.Position.Z())
+		for dy := -1; dy <= 1; dy++ {
+			for dx := -1; dx <= 1; dx++ {
+				c.WritePixel(x+dx, y+dy, plotTick)
+			}
+		}
+	}
+
+	return c
+}
+
+// drawLine draws a straight line between two pixels using Bresenham's algorithm.
+func drawLine(c *canvas.Canvas, x0, y0, x1, y1 int, color canvas.Color) {
+	dx, dy := abs(x1-x0), -abs(y1-y0)
+	sx, sy := sign(x1-x0), sign(y1-y0)
+	err := dx + dy
+	for {
+		c.WritePixel(x0, y0, color)
+		if x0 == x1 && y0 == y1 {
+			return
+		}
+		e2 := 2 * err
+		if e2 >= dy {
+			err += dy
+			x0 += sx
+		}
+		if e2 <= dx {
+			err += dx
+			y0 += sy
+		}
+	}
+}
+
+func abs(v int) int {
+	if v < 0 {
+		return -v
+	}
+	return v
+}
+
+func sign(v int) int {
+	switch {
+	case v < 0:
+		return -1
+	case v > 0:
+		return 1
+	default:
+		return 0
+	}
+}
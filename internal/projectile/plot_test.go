@@ -0,0 +1,59 @@
+package projectile
+
+import (
+	"testing"
+
+	"github.com/seanpk/go-for-rays/internal/geometry"
+)
+
+func TestPlot(t *testing.T) {
+	env := Environment{Gravity: geometry.NewVector(0, 0, -1), Wind: geometry.NewVector(0, 0, 0)}
+	launch := Projectile{Position: geometry.NewPoint(0, 0, 0), Velocity: geometry.NewVector(1, 0, 3)}
+	trajectory := Simulate(env, launch)
+
+	c := Plot(trajectory, 100, 60)
+	if c.Width() != 100 || c.Height() != 60 {
+		t.Fatalf("Plot() size = %dx%d, want 100x60", c.Width(), c.Height())
+	}
+
+	// the trajectory spans x in [0, 7] and z in [0, 6], so the scale is min(80/7, 40/6) pixels per unit
+	tests := []struct {
+		name string
+		x, y int
+	}{
+		{name: "launch at the origin", x: 10, y: 49},
+		{name: "apex at (3, 6)", x: 10 + 20, y: 49 - 40},
+		{name: "landing at (7, 0)", x: 10 + 47, y: 49},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := c.PixelAt(tt.x, tt.y); got != plotTick {
+				t.Errorf("PixelAt(%d, %d) = %v, want %v", tt.x, tt.y, got, plotTick)
+			}
+		})
+	}
+
+	if got := c.PixelAt(99, 49); got != plotAxes {
+		t.Errorf("ground axis PixelAt(99, 49) = %v, want %v", got, plotAxes)
+	}
+	if got := c.PixelAt(10, 0); got != plotAxes {
+		t.Errorf("vertical axis PixelAt(10, 0) = %v, want %v", got, plotAxes)
+	}
+}
+
+func TestPlotOnTinyCanvas(t *testing.T) {
+	env := Environment{Gravity: geometry.NewVector(0, 0, -1), Wind: geometry.NewVector(0, 0, 0)}
+	launch := Projectile{Position: geometry.NewPoint(0, 0, 0), Velocity: geometry.NewVector(1, 0, 3)}
+	trajectory := Simulate(env, launch)
+
+	// the margin shrinks to 2 pixels, leaving 1 pixel per 7 units, so the whole flight stays on the canvas
+	c := Plot(trajectory, 5, 5)
+	for _, p := range [][2]int{{2, 2}, {3, 2}} {
+		if got := c.PixelAt(p[0], p[1]); got != plotTick {
+			t.Errorf("PixelAt(%d, %d) = %v, want %v", p[0], p[1], got, plotTick)
+		}
+	}
+	if got := c.PixelAt(0, 0); got != plotBackground {
+		t.Errorf("corner PixelAt(0, 0) = %v, want %v", got, plotBackground)
+	}
+}
@@ -0,0 +1,245 @@
+package projectile
+
+import (
+	"math"
+	"slices"
+
+	"github.com/seanpk/go-for-rays/internal/geometry"
+)
+
+// Projectile is a body in flight, described by its current position (a point) and velocity (a vector).
+type Projectile struct {
+	Position geometry.HomogeneousTuple
+	Velocity geometry.HomogeneousTuple
+}
+
+// Body describes the physical properties of the projectile that determine how the air slows it down.
+type Body struct {
+	Mass            float64 // kg
+	DragCoefficient float64 // dimensionless
+	Area            float64 // cross-section area in m^2
+}
+
+// Environment holds the forces acting on a projectile.
+// Without drag, Gravity and Wind are accelerations applied to the projectile's velocity.
+// With drag (see HasDrag), Wind is instead the velocity of the air mass, and it acts on the
+// projectile through the drag force computed from the projectile's velocity relative to the air.
+type Environment struct {
+	Gravity    geometry.HomogeneousTuple
+	Wind       geometry.HomogeneousTuple
+	AirDensity float64 // kg/m^3
+	Body       Body
+}
+
+// HasDrag reports whether the environment and body define a non-zero drag force.
+func (env Environment) HasDrag() bool {
+	return env.AirDensity > 0 && env.Body.Mass > 0 && env.Body.DragCoefficient > 0 && env.Body.Area > 0
+}
+
+// Acceleration returns the acceleration the environment applies to the projectile in its current state.
+func (env Environment) Acceleration(p Projectile) geometry.HomogeneousTuple {
+	if !env.HasDrag() {
+		return env.Gravity.Add(env.Wind)
+	}
+	return env.Gravity.Add(env.Drag(p).Divide(env.Body.Mass))
+}
+
+// Drag returns the aerodynamic drag force on the projectile: F = -1/2 * rho * Cd * A * |v|^2 * v/|v|,
+// where v is the velocity of the projectile relative to the wind.
+// It returns a zero vector if the environment has no drag or the projectile moves with the air.
+func (env Environment) Drag(p Projectile) geometry.HomogeneousTuple {
+	relative := p.Velocity.Subtract(env.Wind)
+	speed := relative.Magnitude()
+	if !env.HasDrag() || speed == 0 {
+		return geometry.NewVector(0, 0, 0)
+	}
+
+	magnitude := 0.5 * env.AirDensity * env.Body.DragCoefficient * env.Body.Area * speed * speed
+	return relative.Normalize().Multiply(-magnitude)
+}
+
+// Sample records the state of the projectile at a given tick of the simulation.
+type Sample struct {
+	Tick int
+	Time float64
+	Projectile
+}
+
+// StopReason explains why a simulation ended.
+type StopReason string
+
+const (
+	StopLanded      StopReason = "landed"       // came to rest on the ground
+	StopHitObstacle StopReason = "hit obstacle" // came to rest on an obstacle other than the ground
+	StopMaxTicks    StopReason = "max ticks"    // still moving when the tick limit was reached
+)
+
+// Trajectory is the ordered list of samples produced by a simulation, starting with the launch state.
+type Trajectory struct {
+	Samples  []Sample
+	Stop     StopReason
+	Obstacle string // name of the obstacle the projectile stopped on, if any
+	Bounces  int
+}
+
+// Summary captures the key figures of a simulated flight.
+type Summary struct {
+	Ticks      int                       // number of ticks simulated
+	FlightTime float64                   // elapsed time until the projectile stopped
+	Apex       geometry.HomogeneousTuple // highest point reached during the flight
+	Landing    geometry.HomogeneousTuple // final position of the projectile
+	Landed     bool                      // true when the projectile came to rest on the ground
+	Stop       StopReason
+	Obstacle   string
+	Bounces    int
+}
+
+// DefaultMaxTicks bounds a simulation whose projectile never comes back down.
+const DefaultMaxTicks = 10000
+
+// DefaultTimeStep is the time step used when none is specified; one unit of time per tick.
+const DefaultTimeStep = 1.0
+
+type SimulationOptions struct {
+	Integrator Integrator // default: explicit Euler
+	TimeStep   float64    // must be positive (default: DefaultTimeStep)
+	MaxTicks   int        // must be positive (default: DefaultMaxTicks)
+	Obstacles  []Obstacle // default: DefaultGround; DefaultGround is also added when none of the obstacles is the ground
+}
+
+func resolveSimulationOptions(options ...SimulationOptions) SimulationOptions {
+	var opts SimulationOptions
+	if len(options) > 0 {
+		opts = options[0]
+	}
+	if opts.Integrator == nil {
+		opts.Integrator = integrators[DefaultIntegrator]
+	}
+	if !(opts.TimeStep > 0) {
+		opts.TimeStep = DefaultTimeStep
+	}
+	if opts.MaxTicks <= 0 {
+		opts.MaxTicks = DefaultMaxTicks
+	}
+	if !slices.ContainsFunc(opts.Obstacles, func(o Obstacle) bool { return o.Ground }) {
+		opts.Obstacles = append(slices.Clip(opts.Obstacles), DefaultGround)
+	}
+	return opts
+}
+
+// Simulate steps the projectile until it comes to rest on the ground or an obstacle, or the maximum number of ticks have elapsed.
+// Collisions are detected between ticks, and the contact is located with sub-steps of the integrator.
+// On contact the projectile stops, or bounces if the obstacle has a restitution; a bounce too weak to lift
+// the projectile for a full tick brings it to rest. The projectile always takes at least one step,
+// so a launch from the ground is allowed.
+func Simulate(env Environment, p Projectile, options ...SimulationOptions) Trajectory {
+	opts := resolveSimulationOptions(options...)
+	dt := opts.TimeStep
+	restingSpeed := math.Max(env.Gravity.Magnitude()*dt, geometry.EPSILON)
+
+	trajectory := Trajectory{Samples: []Sample{{Tick: 0, Time: 0, Projectile: p}}, Stop: StopMaxTicks}
+	time := 0.0
+	for tick := 1; tick <= opts.MaxTicks; tick++ {
+		next := opts.Integrator.Step(env, p, dt)
+
+		obstacle, ok := firstCrossing(opts.Obstacles, p, next)
+		if !ok {
+			p, time = next, time+dt
+			trajectory.Samples = append(trajectory.Samples, Sample{Tick: tick, Time: time, Projectile: p})
+			continue
+		}
+
+		contact, fraction, normal := locateContact(env, opts.Integrator, obstacle.Surface, p, next, dt)
+		p, time = contact, time+fraction*dt
+		trajectory.Samples = append(trajectory.Samples, Sample{Tick: tick, Time: time, Projectile: p})
+
+		bounced := Bounce(p.Velocity, normal, obstacle.Restitution)
+		if obstacle.Restitution <= 0 || bounced.DotProduct(normal) < restingSpeed {
+			trajectory.Obstacle = obstacle.Name
+			if obstacle.Ground {
+				trajectory.Stop = StopLanded
+			} else {
+				trajectory.Stop = StopHitObstacle
+			}
+			break
+		}
+		p.Velocity = bounced
+		trajectory.Bounces++
+	}
+
+	return trajectory
+}
+
+// firstCrossing returns the obstacle the straight segment between two states crosses first.
+func firstCrossing(obstacles []Obstacle, from, to Projectile) (Obstacle, bool) {
+	var first Obstacle
+	found, earliest := false, math.Inf(1)
+	for _, o := range obstacles {
+		if fraction, _, ok := o.Surface.Crossing(from.Position, to.Position); ok && fraction < earliest {
+			first, found, earliest = o, true, fraction
+		}
+	}
+	return first, found
+}
+
+// contactRefinements bounds the number of sub-steps used to locate a contact.
+const contactRefinements = 20
+
+// locateContact finds the state at which the projectile meets the surface during the step from one state to the next.
+// Starting from the straight-line estimate, it repeatedly takes a partial step with the integrator, keeping the
+// contact bracketed between a fraction of the step where the surface has not been crossed and one where it has.
+// It returns the state on the open side of the bracket, the fraction of the step, and the surface normal.
+func locateContact(env Environment, integrator Integrator, surface Surface, from, to Projectile, dt float64) (Projectile, float64, geometry.HomogeneousTuple) {
+	fraction, normal, _ := surface.Crossing(from.Position, to.Position)
+	low, high := 0.0, 1.0
+	for range contactRefinements {
+		sub := integrator.Step(env, from, fraction*dt)
+		if g, n, ok := surface.Crossing(from.Position, sub.Position); ok {
+			normal = n
+			if g == 1 {
+				low = fraction // exactly on the surface
+				break
+			}
+			high, fraction = fraction, fraction*g
+		} else if g, n, ok := surface.Crossing(sub.Position, to.Position); ok {
+			normal = n
+			low, fraction = fraction, fraction+(1-fraction)*g
+		} else {
+			break
+		}
+		if !(fraction > low && fraction < high) {
+			fraction = (low + high) / 2
+		}
+		if high-low < geometry.EPSILON {
+			break
+		}
+	}
+
+	return integrator.Step(env, from, low*dt), low, normal
+}
+
+// Summarize reports the flight time, apex, final position and stop reason of the trajectory.
+func (t Trajectory) Summarize() Summary {
+	if len(t.Samples) == 0 {
+		return Summary{FlightTime: math.NaN(), Apex: geometry.NaNTuple(), Landing: geometry.NaNTuple(), Stop: t.Stop}
+	}
+
+	apex := t.Samples[0].Position
+	for _, s := range t.Samples[1:] {
+		if s.Position.Z() > apex.Z() {
+			apex = s.Position
+		}
+	}
+
+	last := t.Samples[len(t.Samples)-1]
+	return Summary{
+		Ticks:      last.Tick,
+		FlightTime: last.Time,
+		Apex:       apex,
+		Landing:    last.Position,
+		Landed:     t.Stop == StopLanded,
+		Stop:       t.Stop,
+		Obstacle:   t.Obstacle,
+		Bounces:    t.Bounces,
+	}
+}
@@ -0,0 +1,90 @@
+package projectile
+
+import (
+	"testing"
+
+	"github.com/seanpk/go-for-rays/internal/geometry"
+)
+
+func TestSimulate(t *testing.T) {
+	tests := []struct {
+		name       string
+		env        Environment
+		projectile Projectile
+		maxTicks   int
+		flightTime float64
+		apexZ      float64
+		landing    geometry.HomogeneousTuple
+		landed     bool
+	}{
+		{
+			name:       "lands after a parabolic arc",
+			env:        Environment{Gravity: geometry.NewVector(0, 0, -1), Wind: geometry.NewVector(0, 0, 0)},
+			projectile: Projectile{Position: geometry.NewPoint(0, 0, 0), Velocity: geometry.NewVector(1, 0, 3)},
+			flightTime: 7, apexZ: 6, landing: geometry.NewPoint(7, 0, 0), landed: true,
+		},
+		{
+			name:       "stops at max ticks when it never comes down",
+			env:        Environment{Gravity: geometry.NewVector(0, 0, 0), Wind: geometry.NewVector(0, 0, 0)},
+			projectile: Projectile{Position: geometry.NewPoint(0, 0, 1), Velocity: geometry.NewVector(0, 0, 1)},
+			maxTicks:   5,
+			flightTime: 5, apexZ: 6, landing: geometry.NewPoint(0, 0, 6), landed: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			summary := Simulate(tt.env, tt.projectile, SimulationOptions{MaxTicks: tt.maxTicks}).Summarize()
+			if summary.FlightTime != tt.flightTime {
+				t.Errorf("FlightTime = %v, want %v", summary.FlightTime, tt.flightTime)
+			}
+			if !geometry.IsNearTo(summary.Apex.Z(), tt.apexZ) {
+				t.Errorf("Apex.Z() = %v, want %v", summary.Apex.Z(), tt.apexZ)
+			}
+			if !summary.Landing.Equals(tt.landing) {
+				t.Errorf("Landing = %v, want %v", summary.Landing, tt.landing)
+			}
+			if summary.Landed != tt.landed {
+				t.Errorf("Landed = %v, want %v", summary.Landed, tt.landed)
+			}
+		})
+	}
+}
+
+func TestDrag(t *testing.T) {
+	body := Body{Mass: 2, DragCoefficient: 0.5, Area: 0.1}
+	tests := []struct {
+		name     string
+		env      Environment
+		velocity geometry.HomogeneousTuple
+		expected geometry.HomogeneousTuple
+	}{
+		{name: "opposes motion in still air", env: Environment{Wind: geometry.NewVector(0, 0, 0), AirDensity: 1.2, Body: body}, velocity: geometry.NewVector(10, 0, 0), expected: geometry.NewVector(-3, 0, 0)},
+		{name: "uses velocity relative to the wind", env: Environment{Wind: geometry.NewVector(0, 0, 10), AirDensity: 1.2, Body: body}, velocity: geometry.NewVector(0, 0, 0), expected: geometry.NewVector(0, 0, 3)},
+		{name: "zero when moving with the wind", env: Environment{Wind: geometry.NewVector(5, 0, 0), AirDensity: 1.2, Body: body}, velocity: geometry.NewVector(5, 0, 0), expected: geometry.NewVector(0, 0, 0)},
+		{name: "zero without air", env: Environment{Wind: geometry.NewVector(0, 0, 0), Body: body}, velocity: geometry.NewVector(10, 0, 0), expected: geometry.NewVector(0, 0, 0)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := Projectile{Position: geometry.NewPoint(0, 0, 1), Velocity: tt.velocity}
+			if got := tt.env.Drag(p); !got.Equals(tt.expected) {
+				t.Errorf("Drag() = %v, want %v", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestAccelerationWithDrag(t *testing.T) {
+	env := Environment{
+		Gravity:    geometry.NewVector(0, 0, -9.81),
+		Wind:       geometry.NewVector(0, 0, 0),
+		AirDensity: 1.2,
+		Body:       Body{Mass: 2, DragCoefficient: 0.5, Area: 0.1},
+	}
+	p := Projectile{Position: geometry.NewPoint(0, 0, 1), Velocity: geometry.NewVector(10, 0, 0)}
+
+	if got, want := env.Acceleration(p), geometry.NewVector(-1.5, 0, -9.81); !got.Equals(want) {
+		t.Errorf("Acceleration() = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,110 @@
+package projectile
+
+import (
+	"fmt"
+	"math"
+	"runtime"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/seanpk/go-for-rays/internal/geometry"
+)
+
+// LaunchVelocity converts a launch speed and direction into a velocity vector.
+// The elevation is measured up from the xy plane and the azimuth counterclockwise from the x axis, both in radians.
+func LaunchVelocity(speed, elevation, azimuth float64) geometry.HomogeneousTuple {
+	horizontal := speed * math.Cos(elevation)
+	return geometry.NewVector(
+		horizontal*math.Cos(azimuth),
+		horizontal*math.Sin(azimuth),
+		speed*math.Sin(elevation),
+	)
+}
+
+// SweepCase is one launch configuration of a parameter sweep; angles are in radians.
+type SweepCase struct {
+	Elevation float64
+	Azimuth   float64
+	Speed     float64
+}
+
+// SweepResult is the outcome of simulating a single SweepCase.
+type SweepResult struct {
+	SweepCase
+	Summary
+	Range float64 // horizontal distance from the launch point to the landing point
+}
+
+// Sweep simulates every case from the same launch point, spreading the work across up to workers goroutines.
+// A non-positive workers uses one goroutine per CPU. The results are in the same order as the cases.
+func Sweep(env Environment, launchPoint geometry.HomogeneousTuple, cases []SweepCase, options SimulationOptions, workers int) []SweepResult {
+	if workers <= 0 {
+		workers = runtime.NumCPU()
+	}
+
+	results := make([]SweepResult, len(cases))
+	indices := make(chan int)
+	var wg sync.WaitGroup
+	for range min(workers, len(cases)) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indices {
+				results[i] = simulateCase(env, launchPoint, cases[i], options)
+			}
+		}()
+	}
+	for i := range cases {
+		indices <- i
+	}
+	close(indices)
+	wg.Wait()
+
+	return results
+}
+
+func simulateCase(env Environment, launchPoint geometry.HomogeneousTuple, c SweepCase, options SimulationOptions) SweepResult {
+	launch := Projectile{Position: launchPoint, Velocity: LaunchVelocity(c.Speed, c.Elevation, c.Azimuth)}
+	summary := Simulate(env, launch, options).Summarize()
+	offset := summary.Landing.Subtract(launchPoint)
+	return SweepResult{
+		SweepCase: c,
+		Summary:   summary,
+		Range:     math.Hypot(offset.X(), offset.Y()),
+	}
+}
+
+// sweepKeys maps the names accepted by SortSweepResults to the figure they sort on.
+var sweepKeys = map[string]func(SweepResult) float64{
+	"range":       func(r SweepResult) float64 { return r.Range },
+	"apex":        func(r SweepResult) float64 { return r.Apex.Z() },
+	"flight-time": func(r SweepResult) float64 { return r.FlightTime },
+}
+
+// SweepSortKeys returns the sorted names of the keys accepted by SortSweepResults.
+func SweepSortKeys() []string {
+	keys := make([]string, 0, len(sweepKeys))
+	for key := range sweepKeys {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// SortSweepResults sorts the results in place from best to worst by the named key, largest first.
+// Results that did not land are sorted after all landed results, since their figures are incomplete.
+func SortSweepResults(results []SweepResult, key string) error {
+	value, ok := sweepKeys[key]
+	if !ok {
+		return fmt.Errorf("unknown sort key %q: expected one of %s", key, strings.Join(SweepSortKeys(), ", "))
+	}
+
+	sort.SliceStable(results, func(i, j int) bool {
+		if results[i].Landed != results[j].Landed {
+			return results[i].Landed
+		}
+		return value(results[i]) > value(results[j])
+	})
+	return nil
+}
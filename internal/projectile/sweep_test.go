@@ -0,0 +1,65 @@
+package projectile
+
+import (
+	"math"
+	"testing"
+
+	"github.com/seanpk/go-for-rays/internal/geometry"
+)
+
+func TestLaunchVelocity(t *testing.T) {
+	tests := []struct {
+		name      string
+		elevation float64
+		azimuth   float64
+		expected  geometry.HomogeneousTuple
+	}{
+		{name: "horizontal along x", elevation: 0, azimuth: 0, expected: geometry.NewVector(2, 0, 0)},
+		{name: "horizontal along y", elevation: 0, azimuth: math.Pi / 2, expected: geometry.NewVector(0, 2, 0)},
+		{name: "straight up", elevation: math.Pi / 2, azimuth: 0, expected: geometry.NewVector(0, 0, 2)},
+		{name: "45 degrees along x", elevation: math.Pi / 4, azimuth: 0, expected: geometry.NewVector(math.Sqrt2, 0, math.Sqrt2)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := LaunchVelocity(2, tt.elevation, tt.azimuth); !got.Equals(tt.expected) {
+				t.Errorf("LaunchVelocity() = %v, want %v", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestSweep(t *testing.T) {
+	env := Environment{Gravity: geometry.NewVector(0, 0, -9.81), Wind: geometry.NewVector(0, 0, 0)}
+	options := SimulationOptions{Integrator: IntegratorFunc(RK4Step), TimeStep: 0.01}
+
+	var cases []SweepCase
+	for degrees := 15.0; degrees <= 75; degrees += 15 {
+		cases = append(cases, SweepCase{Elevation: degrees * math.Pi / 180, Speed: 20})
+	}
+
+	results := Sweep(env, geometry.NewPoint(0, 0, 0), cases, options, 3)
+	for i, r := range results {
+		if r.SweepCase != cases[i] {
+			t.Fatalf("Sweep() result %d is for %+v, want %+v", i, r.SweepCase, cases[i])
+		}
+	}
+
+	if err := SortSweepResults(results, "range"); err != nil {
+		t.Fatalf("SortSweepResults() error = %v", err)
+	}
+	if best := results[0].Elevation * 180 / math.Pi; !geometry.IsNearTo(best, 45) {
+		t.Errorf("best elevation for range = %v, want 45", best)
+	}
+
+	if err := SortSweepResults(results, "apex"); err != nil {
+		t.Fatalf("SortSweepResults() error = %v", err)
+	}
+	if best := results[0].Elevation * 180 / math.Pi; !geometry.IsNearTo(best, 75) {
+		t.Errorf("best elevation for apex = %v, want 75", best)
+	}
+
+	if err := SortSweepResults(results, "distance"); err == nil {
+		t.Errorf("SortSweepResults() expected an error for an unknown key")
+	}
+}
@@ -0,0 +1,54 @@
+package projectile
+
+import (
+	"math"
+
+	"github.com/seanpk/go-for-rays/internal/geometry"
+)
+
+// VacuumPosition is the closed-form position at time t of a projectile launched into a vacuum
+// with constant gravitational acceleration: x(t) = x0 + v0*t + g*t^2/2.
+func VacuumPosition(gravity geometry.HomogeneousTuple, launch Projectile, t float64) geometry.HomogeneousTuple {
+	return launch.Position.Add(launch.Velocity.Multiply(t)).Add(gravity.Multiply(0.5 * t * t))
+}
+
+// VacuumFlightTime is the closed-form time at which a projectile launched into a vacuum returns to z=0.
+// It returns NaN if the projectile never reaches the ground.
+func VacuumFlightTime(gravity geometry.HomogeneousTuple, launch Projectile) float64 {
+	// solve z0 + vz*t + gz*t^2/2 = 0 for the largest root
+	a, b, c := 0.5*gravity.Z(), launch.Velocity.Z(), launch.Position.Z()
+	if a == 0 {
+		if b == 0 {
+			return math.NaN()
+		}
+		if t := -c / b; t >= 0 {
+			return t
+		}
+		return math.NaN()
+	}
+
+	discriminant := b*b - 4*a*c
+	if discriminant < 0 {
+		return math.NaN()
+	}
+	t := math.Max((-b+math.Sqrt(discriminant))/(2*a), (-b-math.Sqrt(discriminant))/(2*a))
+	if t < 0 {
+		return math.NaN()
+	}
+	return t
+}
+
+// VacuumError is the largest distance between a simulated sample and the closed-form vacuum position at the same time.
+func VacuumError(gravity geometry.HomogeneousTuple, trajectory Trajectory) float64 {
+	if len(trajectory.Samples) == 0 {
+		return math.NaN()
+	}
+
+	launch := trajectory.Samples[0].Projectile
+	maxError := 0.0
+	for _, s := range trajectory.Samples {
+		expected := VacuumPosition(gravity, launch, s.Time)
+		maxError = math.Max(maxError, s.Position.Subtract(expected).Magnitude())
+	}
+	return maxError
+}
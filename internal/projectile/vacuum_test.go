@@ -0,0 +1,34 @@
+package projectile
+
+import (
+	"math"
+	"testing"
+
+	"github.com/seanpk/go-for-rays/internal/geometry"
+)
+
+func TestVacuumFlightTime(t *testing.T) {
+	tests := []struct {
+		name     string
+		gravity  geometry.HomogeneousTuple
+		launch   Projectile
+		expected float64
+	}{
+		{name: "from the ground", gravity: geometry.NewVector(0, 0, -10), launch: Projectile{Position: geometry.NewPoint(0, 0, 0), Velocity: geometry.NewVector(1, 0, 10)}, expected: 2},
+		{name: "from a height", gravity: geometry.NewVector(0, 0, -10), launch: Projectile{Position: geometry.NewPoint(0, 0, 15), Velocity: geometry.NewVector(1, 0, 10)}, expected: 3},
+		{name: "no gravity, rising", gravity: geometry.NewVector(0, 0, 0), launch: Projectile{Position: geometry.NewPoint(0, 0, 1), Velocity: geometry.NewVector(0, 0, 1)}, expected: math.NaN()},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := VacuumFlightTime(tt.gravity, tt.launch)
+			if math.IsNaN(tt.expected) {
+				if !math.IsNaN(got) {
+					t.Errorf("VacuumFlightTime() = %v, want NaN", got)
+				}
+			} else if !geometry.IsNearTo(got, tt.expected) {
+				t.Errorf("VacuumFlightTime() = %v, want %v", got, tt.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,52 @@
+package ray
+
+import (
+	"fmt"
+
+	"github.com/seanpk/go-for-rays/internal/geometry"
+)
+
+// Ray is a half-line starting at an origin point and extending along a direction vector.
+type Ray struct {
+	origin    geometry.HomogeneousTuple
+	direction geometry.HomogeneousTuple
+}
+
+// New creates a ray from an origin point and a direction vector.
+// The direction is not normalized, so that distances along transformed rays stay comparable.
+// It returns an error if the origin is not a point or the direction is not a vector.
+func New(origin, direction geometry.HomogeneousTuple) (Ray, error) {
+	if !origin.IsPoint() {
+		return Ray{}, fmt.Errorf("ray origin must be a point, got %s", origin.String())
+	}
+	if !direction.IsVector() {
+		return Ray{}, fmt.Errorf("ray direction must be a vector, got %s", direction.String())
+	}
+	return Ray{origin: origin, direction: direction}, nil
+}
+
+func (r Ray) Origin() geometry.HomogeneousTuple {
+	return r.origin
+}
+
+func (r Ray) Direction() geometry.HomogeneousTuple {
+	return r.direction
+}
+
+// Position returns the point at distance t along the ray, measured in multiples of the direction vector.
+func (r Ray) Position(t float64) geometry.HomogeneousTuple {
+	return r.origin.Add(r.direction.Multiply(t))
+}
+
+// Transform returns the ray with its origin and direction transformed by the matrix.
+// Since the direction is a vector, it is unaffected by any translation in the matrix.
+func (r Ray) Transform(m geometry.Matrix4) Ray {
+	return Ray{
+		origin:    m.MultiplyTuple(r.origin),
+		direction: m.MultiplyTuple(r.direction),
+	}
+}
+
+func (r Ray) String() string {
+	return fmt.Sprintf("Ray(%s, %s)", r.origin.String(), r.direction.String())
+}
@@ -0,0 +1,84 @@
+package ray
+
+import (
+	"testing"
+
+	"github.com/seanpk/go-for-rays/internal/geometry"
+)
+
+func TestNew(t *testing.T) {
+	tests := []struct {
+		name      string
+		origin    geometry.HomogeneousTuple
+		direction geometry.HomogeneousTuple
+		wantErr   bool
+	}{
+		{name: "point and vector", origin: geometry.NewPoint(1, 2, 3), direction: geometry.NewVector(4, 5, 6)},
+		{name: "vector origin", origin: geometry.NewVector(1, 2, 3), direction: geometry.NewVector(4, 5, 6), wantErr: true},
+		{name: "point direction", origin: geometry.NewPoint(1, 2, 3), direction: geometry.NewPoint(4, 5, 6), wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, err := New(tt.origin, tt.direction)
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("New() = %v, want an error", r)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("New() error = %v", err)
+			}
+			if !r.Origin().Equals(tt.origin) || !r.Direction().Equals(tt.direction) {
+				t.Errorf("New() = %v, want Ray(%v, %v)", r, tt.origin, tt.direction)
+			}
+		})
+	}
+}
+
+func TestPosition(t *testing.T) {
+	r, _ := New(geometry.NewPoint(2, 3, 4), geometry.NewVector(1, 0, 0))
+
+	tests := []struct {
+		t        float64
+		expected geometry.HomogeneousTuple
+	}{
+		{t: 0, expected: geometry.NewPoint(2, 3, 4)},
+		{t: 1, expected: geometry.NewPoint(3, 3, 4)},
+		{t: -1, expected: geometry.NewPoint(1, 3, 4)},
+		{t: 2.5, expected: geometry.NewPoint(4.5, 3, 4)},
+	}
+
+	for _, tt := range tests {
+		if got := r.Position(tt.t); !got.Equals(tt.expected) {
+			t.Errorf("Position(%v) = %v, want %v", tt.t, got, tt.expected)
+		}
+	}
+}
+
+func TestTransform(t *testing.T) {
+	r, _ := New(geometry.NewPoint(1, 2, 3), geometry.NewVector(0, 1, 0))
+
+	tests := []struct {
+		name      string
+		transform geometry.Matrix4
+		origin    geometry.HomogeneousTuple
+		direction geometry.HomogeneousTuple
+	}{
+		{name: "translation", transform: geometry.Translation(3, 4, 5), origin: geometry.NewPoint(4, 6, 8), direction: geometry.NewVector(0, 1, 0)},
+		{name: "scaling", transform: geometry.Scaling(2, 3, 4), origin: geometry.NewPoint(2, 6, 12), direction: geometry.NewVector(0, 3, 0)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := r.Transform(tt.transform)
+			if !got.Origin().Equals(tt.origin) || !got.Direction().Equals(tt.direction) {
+				t.Errorf("Transform() = %v, want Ray(%v, %v)", got, tt.origin, tt.direction)
+			}
+			if !r.Origin().Equals(geometry.NewPoint(1, 2, 3)) {
+				t.Errorf("Transform() modified the original ray: %v", r)
+			}
+		})
+	}
+}
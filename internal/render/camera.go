@@ -0,0 +1,127 @@
+package render
+
+import (
+	"fmt"
+	"math"
+	"runtime"
+	"sync"
+
+	"github.com/seanpk/go-for-rays/internal/canvas"
+	"github.com/seanpk/go-for-rays/internal/geometry"
+	"github.com/seanpk/go-for-rays/internal/ray"
+)
+
+// Camera maps the pixels of a canvas to rays into the world.
+// The canvas sits one unit in front of the camera, which looks down -z in its own space;
+// the view transform orients the world relative to the camera.
+type Camera struct {
+	hsize       int
+	vsize       int
+	fieldOfView float64
+	transform   geometry.Matrix4
+	inverse     geometry.Matrix4
+	halfWidth   float64
+	halfHeight  float64
+	pixelSize   float64
+}
+
+// NewCamera creates a camera rendering hsize x vsize pixels with the given horizontal or vertical
+// field of view (whichever side of the canvas is longer), in radians.
+// It returns an error unless both sizes are positive and the field of view is between 0 and pi.
+func NewCamera(hsize, vsize int, fieldOfView float64) (*Camera, error) {
+	if hsize <= 0 || vsize <= 0 {
+		return nil, fmt.Errorf("invalid camera size: %dx%d must be positive", hsize, vsize)
+	}
+	if !(fieldOfView > 0 && fieldOfView < math.Pi) {
+		return nil, fmt.Errorf("invalid field of view: %v must be between 0 and pi", fieldOfView)
+	}
+
+	c := &Camera{
+		hsize:       hsize,
+		vsize:       vsize,
+		fieldOfView: fieldOfView,
+		transform:   geometry.Identity(),
+		inverse:     geometry.Identity(),
+	}
+	halfView := math.Tan(fieldOfView / 2)
+	aspect := float64(hsize) / float64(vsize)
+	if aspect >= 1 {
+		c.halfWidth, c.halfHeight = halfView, halfView/aspect
+	} else {
+		c.halfWidth, c.halfHeight = halfView*aspect, halfView
+	}
+	c.pixelSize = c.halfWidth * 2 / float64(hsize)
+	return c, nil
+}
+
+func (c *Camera) HSize() int {
+	return c.hsize
+}
+
+func (c *Camera) VSize() int {
+	return c.vsize
+}
+
+func (c *Camera) FieldOfView() float64 {
+	return c.fieldOfView
+}
+
+// PixelSize returns the size of a pixel on the canvas, one unit in front of the camera.
+func (c *Camera) PixelSize() float64 {
+	return c.pixelSize
+}
+
+func (c *Camera) Transform() geometry.Matrix4 {
+	return c.transform
+}
+
+// SetTransform sets the view transform, typically from geometry.ViewTransform.
+// It returns geometry.ErrNotInvertible, and leaves the transform unchanged, if the matrix cannot be inverted.
+func (c *Camera) SetTransform(m geometry.Matrix4) error {
+	inverse, err := m.Inverse()
+	if err != nil {
+		return err
+	}
+	c.transform, c.inverse = m, inverse
+	return nil
+}
+
+// RayForPixel returns the ray from the camera through the center of the pixel at (px, py).
+func (c *Camera) RayForPixel(px, py int) ray.Ray {
+	worldX := c.halfWidth - (float64(px)+0.5)*c.pixelSize
+	worldY := c.halfHeight - (float64(py)+0.5)*c.pixelSize
+
+	pixel := c.inverse.MultiplyTuple(geometry.NewPoint(worldX, worldY, -1))
+	origin := c.inverse.MultiplyTuple(geometry.NewPoint(0, 0, 0))
+	// the inverse of an affine transform maps points to points, so the ray is always valid
+	r, _ := ray.New(origin, pixel.Subtract(origin).Normalize())
+	return r
+}
+
+// Render renders the world to a new canvas, one ray per pixel.
+// Rows are rendered in parallel, so the world must not change during the render.
+func (c *Camera) Render(w *World, options ...RenderOptions) *canvas.Canvas {
+	opts := resolveRenderOptions(options...)
+	image := canvas.NewCanvas(c.hsize, c.vsize)
+
+	rows := make(chan int)
+	var wg sync.WaitGroup
+	for range min(runtime.NumCPU(), c.vsize) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for y := range rows {
+				for x := 0; x < c.hsize; x++ {
+					image.WritePixel(x, y, w.ColorAt(c.RayForPixel(x, y), opts))
+				}
+			}
+		}()
+	}
+	for y := 0; y < c.vsize; y++ {
+		rows <- y
+	}
+	close(rows)
+	wg.Wait()
+
+	return image
+}
@@ -0,0 +1,121 @@
+package render
+
+import (
+	"math"
+	"testing"
+
+	"github.com/seanpk/go-for-rays/internal/canvas"
+	"github.com/seanpk/go-for-rays/internal/geometry"
+)
+
+func TestNewCamera(t *testing.T) {
+	tests := []struct {
+		name        string
+		hsize       int
+		vsize       int
+		fieldOfView float64
+		pixelSize   float64
+		wantErr     bool
+	}{
+		{name: "horizontal canvas", hsize: 200, vsize: 125, fieldOfView: math.Pi / 2, pixelSize: 0.01},
+		{name: "vertical canvas", hsize: 125, vsize: 200, fieldOfView: math.Pi / 2, pixelSize: 0.01},
+		{name: "zero width", hsize: 0, vsize: 200, fieldOfView: math.Pi / 2, wantErr: true},
+		{name: "negative height", hsize: 200, vsize: -1, fieldOfView: math.Pi / 2, wantErr: true},
+		{name: "zero field of view", hsize: 200, vsize: 125, fieldOfView: 0, wantErr: true},
+		{name: "field of view of pi", hsize: 200, vsize: 125, fieldOfView: math.Pi, wantErr: true},
+		{name: "NaN field of view", hsize: 200, vsize: 125, fieldOfView: math.NaN(), wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, err := NewCamera(tt.hsize, tt.vsize, tt.fieldOfView)
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("NewCamera() expected an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("NewCamera() error = %v", err)
+			}
+			if c.HSize() != tt.hsize || c.VSize() != tt.vsize || c.FieldOfView() != tt.fieldOfView {
+				t.Errorf("NewCamera() = (%d, %d, %v), want (%d, %d, %v)", c.HSize(), c.VSize(), c.FieldOfView(), tt.hsize, tt.vsize, tt.fieldOfView)
+			}
+			if !c.Transform().Equals(geometry.Identity()) {
+				t.Errorf("Transform() = %v, want the identity", c.Transform())
+			}
+			if !geometry.IsNearTo(c.PixelSize(), tt.pixelSize) {
+				t.Errorf("PixelSize() = %v, want %v", c.PixelSize(), tt.pixelSize)
+			}
+		})
+	}
+}
+
+func TestRayForPixel(t *testing.T) {
+	s := math.Sqrt2 / 2
+	tests := []struct {
+		name      string
+		transform geometry.Matrix4
+		px, py    int
+		origin    geometry.HomogeneousTuple
+		direction geometry.HomogeneousTuple
+	}{
+		{name: "through the center of the canvas", transform: geometry.Identity(), px: 100, py: 50, origin: geometry.NewPoint(0, 0, 0), direction: geometry.NewVector(0, 0, -1)},
+		{name: "through a corner of the canvas", transform: geometry.Identity(), px: 0, py: 0, origin: geometry.NewPoint(0, 0, 0), direction: geometry.NewVector(0.66519, 0.33259, -0.66851)},
+		{name: "when the camera is transformed", transform: geometry.Translation(0, -2, 5).RotateY(math.Pi / 4), px: 100, py: 50, origin: geometry.NewPoint(0, 2, -5), direction: geometry.NewVector(s, 0, -s)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, err := NewCamera(201, 101, math.Pi/2)
+			if err != nil {
+				t.Fatalf("NewCamera() error = %v", err)
+			}
+			if err := c.SetTransform(tt.transform); err != nil {
+				t.Fatalf("SetTransform() error = %v", err)
+			}
+
+			r := c.RayForPixel(tt.px, tt.py)
+			if !r.Origin().Equals(tt.origin, 1e-5) {
+				t.Errorf("Origin() = %v, want %v", r.Origin(), tt.origin)
+			}
+			if !r.Direction().Equals(tt.direction, 1e-5) {
+				t.Errorf("Direction() = %v, want %v", r.Direction(), tt.direction)
+			}
+		})
+	}
+}
+
+func TestCameraSetTransformNotInvertible(t *testing.T) {
+	c, err := NewCamera(10, 10, math.Pi/2)
+	if err != nil {
+		t.Fatalf("NewCamera() error = %v", err)
+	}
+	if err := c.SetTransform(geometry.Scaling(0, 1, 1)); err == nil {
+		t.Errorf("SetTransform() expected an error")
+	}
+	if !c.Transform().Equals(geometry.Identity()) {
+		t.Errorf("Transform() = %v, want the identity", c.Transform())
+	}
+}
+
+func TestRender(t *testing.T) {
+	w := defaultWorld()
+	c, err := NewCamera(11, 11, math.Pi/2)
+	if err != nil {
+		t.Fatalf("NewCamera() error = %v", err)
+	}
+	from, to, up := geometry.NewPoint(0, 0, -5), geometry.NewPoint(0, 0, 0), geometry.NewVector(0, 1, 0)
+	if err := c.SetTransform(geometry.ViewTransform(from, to, up)); err != nil {
+		t.Fatalf("SetTransform() error = %v", err)
+	}
+
+	image := c.Render(w)
+	if image.Width() != 11 || image.Height() != 11 {
+		t.Fatalf("Render() size = %dx%d, want 11x11", image.Width(), image.Height())
+	}
+	expected := canvas.NewColor(0.38066, 0.47583, 0.2855)
+	if result := image.PixelAt(5, 5); !result.Equals(expected, 1e-4) {
+		t.Errorf("PixelAt(5, 5) = %v, want %v", result, expected)
+	}
+}
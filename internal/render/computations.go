@@ -0,0 +1,95 @@
+package render
+
+import (
+	"math"
+	"slices"
+
+	"github.com/seanpk/go-for-rays/internal/geometry"
+	"github.com/seanpk/go-for-rays/internal/ray"
+	"github.com/seanpk/go-for-rays/internal/shapes"
+)
+
+// Computations holds the values about a hit that shading needs, computed once per hit.
+type Computations struct {
+	T        float64
+	Object   shapes.Shape
+	Point    geometry.HomogeneousTuple // where the ray hits the object, in world space
+	EyeV     geometry.HomogeneousTuple // unit vector pointing back toward the eye
+	NormalV  geometry.HomogeneousTuple // unit surface normal, flipped to face the eye
+	ReflectV geometry.HomogeneousTuple // direction of the ray reflected off the surface
+	Inside   bool                      // whether the hit is on the inside of the object
+
+	// OverPoint is Point moved off the surface along the normal by the render epsilon;
+	// secondary rays start from there so that they do not hit the surface they leave.
+	OverPoint geometry.HomogeneousTuple
+	// UnderPoint is Point moved below the surface by the render epsilon; refracted rays start from there.
+	UnderPoint geometry.HomogeneousTuple
+
+	// N1 and N2 are the refractive indices of the materials the ray leaves and enters at the hit.
+	N1, N2 float64
+}
+
+// PrepareComputations computes the shading values for the intersection hit by the ray.
+// The intersections xs are all those of the ray, which tell which objects contain the hit
+// and so the refractive indices on both sides of it; without them, both indices are 1 (a vacuum).
+func PrepareComputations(hit shapes.Intersection, r ray.Ray, xs shapes.Intersections, options ...RenderOptions) Computations {
+	opts := resolveRenderOptions(options...)
+	point := r.Position(hit.T)
+	comps := Computations{
+		T:       hit.T,
+		Object:  hit.Object,
+		Point:   point,
+		EyeV:    r.Direction().Negate().Normalize(),
+		NormalV: shapes.NormalAt(hit.Object, point),
+	}
+	if comps.NormalV.DotProduct(comps.EyeV) < 0 {
+		comps.Inside = true
+		comps.NormalV = comps.NormalV.Negate()
+	}
+	comps.ReflectV = r.Direction().Reflect(comps.NormalV)
+	comps.OverPoint = point.Add(comps.NormalV.Multiply(opts.Epsilon))
+	comps.UnderPoint = point.Subtract(comps.NormalV.Multiply(opts.Epsilon))
+	comps.N1, comps.N2 = refractiveIndices(hit, xs)
+	return comps
+}
+
+// refractiveIndices walks the intersections up to the hit, tracking the objects the ray is inside,
+// and returns the refractive indices of the innermost object before and after the hit.
+func refractiveIndices(hit shapes.Intersection, xs shapes.Intersections) (n1, n2 float64) {
+	n1, n2 = 1.0, 1.0
+	var containers []shapes.Shape
+	for _, x := range xs {
+		isHit := x == hit
+		if isHit && len(containers) > 0 {
+			n1 = containers[len(containers)-1].Material().RefractiveIndex
+		}
+		if i := slices.Index(containers, x.Object); i >= 0 {
+			containers = slices.Delete(containers, i, i+1)
+		} else {
+			containers = append(containers, x.Object)
+		}
+		if isHit {
+			if len(containers) > 0 {
+				n2 = containers[len(containers)-1].Material().RefractiveIndex
+			}
+			break
+		}
+	}
+	return n1, n2
+}
+
+// Schlick approximates the Fresnel effect: the fraction of the light a transparent surface reflects,
+// which grows as the eye looks at the surface at a grazing angle.
+func Schlick(comps Computations) float64 {
+	cos := comps.EyeV.DotProduct(comps.NormalV)
+	if comps.N1 > comps.N2 {
+		ratio := comps.N1 / comps.N2
+		sin2T := ratio * ratio * (1 - cos*cos)
+		if sin2T > 1 {
+			return 1 // total internal reflection
+		}
+		cos = math.Sqrt(1 - sin2T)
+	}
+	r0 := math.Pow((comps.N1-comps.N2)/(comps.N1+comps.N2), 2)
+	return r0 + (1-r0)*math.Pow(1-cos, 5)
+}
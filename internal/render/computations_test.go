@@ -0,0 +1,175 @@
+package render
+
+import (
+	"math"
+	"testing"
+
+	"github.com/seanpk/go-for-rays/internal/geometry"
+	"github.com/seanpk/go-for-rays/internal/shading"
+	"github.com/seanpk/go-for-rays/internal/shapes"
+)
+
+func TestPrepareComputations(t *testing.T) {
+	tests := []struct {
+		name   string
+		origin geometry.HomogeneousTuple
+		t      float64
+		point  geometry.HomogeneousTuple
+		normal geometry.HomogeneousTuple
+		inside bool
+	}{
+		{name: "hit on the outside", origin: geometry.NewPoint(0, 0, -5), t: 4, point: geometry.NewPoint(0, 0, -1), normal: geometry.NewVector(0, 0, -1), inside: false},
+		{name: "hit on the inside", origin: geometry.NewPoint(0, 0, 0), t: 1, point: geometry.NewPoint(0, 0, 1), normal: geometry.NewVector(0, 0, -1), inside: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := shapes.NewSphere()
+			r := newRay(t, tt.origin, geometry.NewVector(0, 0, 1))
+			comps := PrepareComputations(shapes.NewIntersection(tt.t, s), r, nil)
+
+			if comps.T != tt.t || comps.Object != s {
+				t.Errorf("PrepareComputations() = (t: %v, object: %v), want (t: %v, object: %v)", comps.T, comps.Object, tt.t, s)
+			}
+			if !comps.Point.Equals(tt.point) {
+				t.Errorf("Point = %v, want %v", comps.Point, tt.point)
+			}
+			if expected := geometry.NewVector(0, 0, -1); !comps.EyeV.Equals(expected) {
+				t.Errorf("EyeV = %v, want %v", comps.EyeV, expected)
+			}
+			if !comps.NormalV.Equals(tt.normal) {
+				t.Errorf("NormalV = %v, want %v", comps.NormalV, tt.normal)
+			}
+			if comps.Inside != tt.inside {
+				t.Errorf("Inside = %v, want %v", comps.Inside, tt.inside)
+			}
+		})
+	}
+}
+
+func TestPrepareComputationsOverPoint(t *testing.T) {
+	tests := []struct {
+		name    string
+		options []RenderOptions
+		epsilon float64
+	}{
+		{name: "default epsilon", options: nil, epsilon: geometry.EPSILON},
+		{name: "zero epsilon uses the default", options: []RenderOptions{{Epsilon: 0}}, epsilon: geometry.EPSILON},
+		{name: "epsilon for a tiny scene", options: []RenderOptions{{Epsilon: 1e-12}}, epsilon: 1e-12},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := shapes.NewSphere()
+			s.SetTransform(geometry.Translation(0, 0, 1))
+			r := newRay(t, geometry.NewPoint(0, 0, -5), geometry.NewVector(0, 0, 1))
+			comps := PrepareComputations(shapes.NewIntersection(5, s), r, nil, tt.options...)
+
+			if !(comps.OverPoint.Z() < -tt.epsilon/2) {
+				t.Errorf("OverPoint.Z() = %v, want less than %v", comps.OverPoint.Z(), -tt.epsilon/2)
+			}
+			if !(comps.Point.Z() > comps.OverPoint.Z()) {
+				t.Errorf("Point.Z() = %v, want more than OverPoint.Z() = %v", comps.Point.Z(), comps.OverPoint.Z())
+			}
+			if offset := comps.Point.Subtract(comps.OverPoint).Magnitude(); offset > tt.epsilon*1.01 {
+				t.Errorf("OverPoint is %v from Point, want %v", offset, tt.epsilon)
+			}
+		})
+	}
+}
+
+func TestPrepareComputationsReflectV(t *testing.T) {
+	s := math.Sqrt2 / 2
+	p := shapes.NewPlane()
+	r := newRay(t, geometry.NewPoint(0, 1, -1), geometry.NewVector(0, -s, s))
+	comps := PrepareComputations(shapes.NewIntersection(math.Sqrt2, p), r, nil)
+
+	if expected := geometry.NewVector(0, s, s); !comps.ReflectV.Equals(expected) {
+		t.Errorf("ReflectV = %v, want %v", comps.ReflectV, expected)
+	}
+}
+
+// glassSphere is a sphere of clear glass.
+func glassSphere(transform geometry.Matrix4) *shapes.Sphere {
+	s := shapes.NewSphere()
+	s.SetTransform(transform)
+	m := shading.DefaultMaterial()
+	m.Transparency = 1
+	m.RefractiveIndex = 1.5
+	s.SetMaterial(m)
+	return s
+}
+
+func TestPrepareComputationsRefractiveIndices(t *testing.T) {
+	a := glassSphere(geometry.Scaling(2, 2, 2))
+	b := glassSphere(geometry.Translation(0, 0, -0.25))
+	c := glassSphere(geometry.Translation(0, 0, 0.25))
+	for _, s := range []struct {
+		shape *shapes.Sphere
+		index float64
+	}{{b, 2.0}, {c, 2.5}} {
+		m := s.shape.Material()
+		m.RefractiveIndex = s.index
+		s.shape.SetMaterial(m)
+	}
+
+	r := newRay(t, geometry.NewPoint(0, 0, -4), geometry.NewVector(0, 0, 1))
+	xs := shapes.NewIntersections(
+		shapes.NewIntersection(2, a), shapes.NewIntersection(2.75, b), shapes.NewIntersection(3.25, c),
+		shapes.NewIntersection(4.75, b), shapes.NewIntersection(5.25, c), shapes.NewIntersection(6, a),
+	)
+	expected := []struct{ n1, n2 float64 }{
+		{1.0, 1.5}, {1.5, 2.0}, {2.0, 2.5}, {2.5, 2.5}, {2.5, 1.5}, {1.5, 1.0},
+	}
+	for i, tt := range expected {
+		comps := PrepareComputations(xs[i], r, xs)
+		if comps.N1 != tt.n1 || comps.N2 != tt.n2 {
+			t.Errorf("intersection %d: (N1, N2) = (%v, %v), want (%v, %v)", i, comps.N1, comps.N2, tt.n1, tt.n2)
+		}
+	}
+}
+
+func TestPrepareComputationsUnderPoint(t *testing.T) {
+	s := glassSphere(geometry.Translation(0, 0, 1))
+	r := newRay(t, geometry.NewPoint(0, 0, -5), geometry.NewVector(0, 0, 1))
+	hit := shapes.NewIntersection(5, s)
+	comps := PrepareComputations(hit, r, shapes.NewIntersections(hit))
+
+	if comps.UnderPoint.Z() <= geometry.EPSILON/2 {
+		t.Errorf("UnderPoint.Z() = %v, want more than %v", comps.UnderPoint.Z(), geometry.EPSILON/2)
+	}
+	if comps.Point.Z() >= comps.UnderPoint.Z() {
+		t.Errorf("Point.Z() = %v, want less than UnderPoint.Z() = %v", comps.Point.Z(), comps.UnderPoint.Z())
+	}
+}
+
+func TestSchlick(t *testing.T) {
+	s := math.Sqrt2 / 2
+	tests := []struct {
+		name      string
+		origin    geometry.HomogeneousTuple
+		direction geometry.HomogeneousTuple
+		ts        []float64
+		hit       int
+		expected  float64
+	}{
+		{name: "total internal reflection", origin: geometry.NewPoint(0, 0, s), direction: geometry.NewVector(0, 1, 0), ts: []float64{-s, s}, hit: 1, expected: 1.0},
+		{name: "perpendicular viewing angle", origin: geometry.NewPoint(0, 0, 0), direction: geometry.NewVector(0, 1, 0), ts: []float64{-1, 1}, hit: 1, expected: 0.04},
+		{name: "small angle with n2 > n1", origin: geometry.NewPoint(0, 0.99, -2), direction: geometry.NewVector(0, 0, 1), ts: []float64{1.8589}, hit: 0, expected: 0.48873},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			shape := glassSphere(geometry.Identity())
+			var xs shapes.Intersections
+			for _, x := range tt.ts {
+				xs = append(xs, shapes.NewIntersection(x, shape))
+			}
+			comps := PrepareComputations(xs[tt.hit], newRay(t, tt.origin, tt.direction), xs)
+
+			if result := Schlick(comps); !geometry.IsNearTo(result, tt.expected, 1e-4) {
+				t.Errorf("Schlick() = %v, want %v", result, tt.expected)
+			}
+		})
+	}
+}
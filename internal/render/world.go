@@ -0,0 +1,164 @@
+package render
+
+import (
+	"math"
+
+	"github.com/seanpk/go-for-rays/internal/canvas"
+	"github.com/seanpk/go-for-rays/internal/geometry"
+	"github.com/seanpk/go-for-rays/internal/ray"
+	"github.com/seanpk/go-for-rays/internal/shading"
+	"github.com/seanpk/go-for-rays/internal/shapes"
+)
+
+// World is a scene: the shapes to render and the lights that illuminate them.
+type World struct {
+	Objects []shapes.Shape
+	Lights  []shading.PointLight
+}
+
+// NewWorld creates an empty world, with no objects and no lights.
+func NewWorld() *World {
+	return &World{}
+}
+
+// RenderOptions configures how rays are traced through a world.
+type RenderOptions struct {
+	// Epsilon is how far along the surface normal secondary rays start from a hit, so that rounding errors
+	// do not make a surface shadow itself ("shadow acne"); scenes at a tiny scale need a smaller value.
+	// It must be positive (default: geometry.EPSILON).
+	Epsilon float64
+	// MaxDepth is how many times a ray may bounce off reflective surfaces or bend through transparent ones,
+	// which stops the recursion between mirrors that face each other. Zero traces no reflected or refracted rays
+	// at all (default, when nil: DefaultMaxDepth).
+	MaxDepth *int
+	// TransparentShadows lets light through transparent objects, so that they cast no shadow;
+	// by default every object casts a shadow.
+	TransparentShadows bool
+}
+
+// DefaultMaxDepth is the number of reflections and refractions traced when none is specified.
+const DefaultMaxDepth = 5
+
+func resolveRenderOptions(options ...RenderOptions) RenderOptions {
+	var opts RenderOptions
+	if len(options) > 0 {
+		opts = options[0]
+	}
+	if !(opts.Epsilon > 0) {
+		opts.Epsilon = geometry.EPSILON
+	}
+	if opts.MaxDepth == nil {
+		maxDepth := DefaultMaxDepth
+		opts.MaxDepth = &maxDepth
+	}
+	return opts
+}
+
+// Intersect returns the intersections of a ray with every object in the world, sorted by distance.
+func (w *World) Intersect(r ray.Ray) shapes.Intersections {
+	var xs shapes.Intersections
+	for _, object := range w.Objects {
+		xs = xs.Merge(shapes.Intersect(object, r))
+	}
+	return xs
+}
+
+// ShadeHit returns the color at a prepared hit, summing the contribution of every light,
+// plus the world reflected by the surface and seen through it.
+// The remaining depth is the number of reflections and refractions that may still be traced.
+func (w *World) ShadeHit(comps Computations, remaining int, options ...RenderOptions) canvas.Color {
+	color := canvas.Black
+	material := comps.Object.Material()
+	for _, light := range w.Lights {
+		inShadow := w.IsShadowed(comps.OverPoint, light, options...)
+		color = color.Add(shading.Lighting(material, comps.Object, light, comps.OverPoint, comps.EyeV, comps.NormalV, inShadow))
+	}
+
+	reflected := w.ReflectedColor(comps, remaining, options...)
+	refracted := w.RefractedColor(comps, remaining, options...)
+	if material.Reflective > 0 && material.Transparency > 0 {
+		// the Fresnel effect: glass reflects more, and lets less through, at grazing angles
+		reflectance := Schlick(comps)
+		return color.Add(reflected.Multiply(reflectance)).Add(refracted.Multiply(1 - reflectance))
+	}
+	return color.Add(reflected).Add(refracted)
+}
+
+// ColorAt returns the color seen along a ray: black if it hits nothing, or the shaded color of the hit.
+func (w *World) ColorAt(r ray.Ray, options ...RenderOptions) canvas.Color {
+	opts := resolveRenderOptions(options...)
+	return w.colorAt(r, *opts.MaxDepth, opts)
+}
+
+func (w *World) colorAt(r ray.Ray, remaining int, opts RenderOptions) canvas.Color {
+	xs := w.Intersect(r)
+	hit, ok := xs.Hit()
+	if !ok {
+		return canvas.Black
+	}
+	return w.ShadeHit(PrepareComputations(hit, r, xs, opts), remaining, opts)
+}
+
+// ReflectedColor returns the color reflected by the surface at a prepared hit, scaled by how reflective it is.
+// It is black for a surface that is not reflective, or when no reflections remain.
+func (w *World) ReflectedColor(comps Computations, remaining int, options ...RenderOptions) canvas.Color {
+	reflective := comps.Object.Material().Reflective
+	if remaining <= 0 || reflective == 0 {
+		return canvas.Black
+	}
+	reflectRay, err := ray.New(comps.OverPoint, comps.ReflectV)
+	if err != nil {
+		return canvas.Black
+	}
+	return w.colorAt(reflectRay, remaining-1, resolveRenderOptions(options...)).Multiply(reflective)
+}
+
+// RefractedColor returns the color seen through the surface at a prepared hit, scaled by how transparent it is.
+// It is black for an opaque surface, when no refractions remain, or when the light is totally internally reflected.
+func (w *World) RefractedColor(comps Computations, remaining int, options ...RenderOptions) canvas.Color {
+	transparency := comps.Object.Material().Transparency
+	if remaining <= 0 || transparency == 0 {
+		return canvas.Black
+	}
+
+	// Snell's law: n1 sin(θi) = n2 sin(θt)
+	ratio := comps.N1 / comps.N2
+	cosI := comps.EyeV.DotProduct(comps.NormalV)
+	sin2T := ratio * ratio * (1 - cosI*cosI)
+	if sin2T > 1 {
+		return canvas.Black // total internal reflection
+	}
+	cosT := math.Sqrt(1 - sin2T)
+	direction := comps.NormalV.Multiply(ratio*cosI - cosT).Subtract(comps.EyeV.Multiply(ratio))
+
+	refractRay, err := ray.New(comps.UnderPoint, direction)
+	if err != nil {
+		return canvas.Black
+	}
+	return w.colorAt(refractRay, remaining-1, resolveRenderOptions(options...)).Multiply(transparency)
+}
+
+// IsShadowed reports whether an object lies between the point and the light.
+// The point should be lifted off the surface it is on, as Computations.OverPoint is.
+// With RenderOptions.TransparentShadows, transparent objects do not block the light.
+func (w *World) IsShadowed(point geometry.HomogeneousTuple, light shading.PointLight, options ...RenderOptions) bool {
+	opts := resolveRenderOptions(options...)
+	toLight := light.Position.Subtract(point)
+	distance := toLight.Magnitude()
+	shadowRay, err := ray.New(point, toLight.Normalize())
+	if err != nil {
+		return false
+	}
+	for _, x := range w.Intersect(shadowRay) {
+		if x.T < 0 {
+			continue
+		}
+		if x.T >= distance {
+			return false
+		}
+		if !opts.TransparentShadows || x.Object.Material().Transparency == 0 {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,445 @@
+package render
+
+import (
+	"math"
+	"testing"
+
+	"github.com/seanpk/go-for-rays/internal/canvas"
+	"github.com/seanpk/go-for-rays/internal/geometry"
+	"github.com/seanpk/go-for-rays/internal/ray"
+	"github.com/seanpk/go-for-rays/internal/shading"
+	"github.com/seanpk/go-for-rays/internal/shapes"
+)
+
+func newRay(t *testing.T, origin, direction geometry.HomogeneousTuple) ray.Ray {
+	t.Helper()
+	r, err := ray.New(origin, direction)
+	if err != nil {
+		t.Fatalf("ray.New() error = %v", err)
+	}
+	return r
+}
+
+// defaultWorld is the book's test world: two concentric spheres lit from the upper left.
+func defaultWorld() *World {
+	outer := shapes.NewSphere()
+	m := shading.DefaultMaterial()
+	m.Color = canvas.NewColor(0.8, 1.0, 0.6)
+	m.Diffuse = 0.7
+	m.Specular = 0.2
+	outer.SetMaterial(m)
+
+	inner := shapes.NewSphere()
+	inner.SetTransform(geometry.Scaling(0.5, 0.5, 0.5))
+
+	w := NewWorld()
+	w.Objects = []shapes.Shape{outer, inner}
+	w.Lights = []shading.PointLight{shading.NewPointLight(geometry.NewPoint(-10, 10, -10), canvas.White)}
+	return w
+}
+
+func TestNewWorld(t *testing.T) {
+	w := NewWorld()
+	if len(w.Objects) != 0 || len(w.Lights) != 0 {
+		t.Errorf("NewWorld() = %+v, want an empty world", w)
+	}
+}
+
+func TestWorldIntersect(t *testing.T) {
+	w := defaultWorld()
+	xs := w.Intersect(newRay(t, geometry.NewPoint(0, 0, -5), geometry.NewVector(0, 0, 1)))
+
+	expected := []float64{4, 4.5, 5.5, 6}
+	if len(xs) != len(expected) {
+		t.Fatalf("Intersect() returned %d intersections, want %d", len(xs), len(expected))
+	}
+	for i, x := range xs {
+		if !geometry.IsNearTo(x.T, expected[i]) {
+			t.Errorf("Intersect()[%d].T = %v, want %v", i, x.T, expected[i])
+		}
+	}
+}
+
+func TestShadeHit(t *testing.T) {
+	tests := []struct {
+		name     string
+		light    shading.PointLight
+		origin   geometry.HomogeneousTuple
+		object   int
+		t        float64
+		expected canvas.Color
+	}{
+		{
+			name:     "shading an intersection",
+			light:    shading.NewPointLight(geometry.NewPoint(-10, 10, -10), canvas.White),
+			origin:   geometry.NewPoint(0, 0, -5),
+			object:   0,
+			t:        4,
+			expected: canvas.NewColor(0.38066, 0.47583, 0.2855),
+		},
+		{
+			name:     "shading an intersection from the inside",
+			light:    shading.NewPointLight(geometry.NewPoint(0, 0.25, 0), canvas.White),
+			origin:   geometry.NewPoint(0, 0, 0),
+			object:   1,
+			t:        0.5,
+			expected: canvas.NewColor(0.90498, 0.90498, 0.90498),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := defaultWorld()
+			w.Lights = []shading.PointLight{tt.light}
+			r := newRay(t, tt.origin, geometry.NewVector(0, 0, 1))
+			comps := PrepareComputations(shapes.NewIntersection(tt.t, w.Objects[tt.object]), r, nil)
+
+			if result := w.ShadeHit(comps, DefaultMaxDepth); !result.Equals(tt.expected, 1e-4) {
+				t.Errorf("ShadeHit() = %v, want %v", result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestShadeHitInShadow(t *testing.T) {
+	w := NewWorld()
+	w.Lights = []shading.PointLight{shading.NewPointLight(geometry.NewPoint(0, 0, -10), canvas.White)}
+	s1 := shapes.NewSphere()
+	s2 := shapes.NewSphere()
+	s2.SetTransform(geometry.Translation(0, 0, 10))
+	w.Objects = []shapes.Shape{s1, s2}
+
+	r := newRay(t, geometry.NewPoint(0, 0, 5), geometry.NewVector(0, 0, 1))
+	comps := PrepareComputations(shapes.NewIntersection(4, s2), r, nil)
+	expected := canvas.NewColor(0.1, 0.1, 0.1)
+	if result := w.ShadeHit(comps, DefaultMaxDepth); !result.Equals(expected, 1e-4) {
+		t.Errorf("ShadeHit() = %v, want %v", result, expected)
+	}
+}
+
+func TestIsShadowed(t *testing.T) {
+	tests := []struct {
+		name     string
+		point    geometry.HomogeneousTuple
+		expected bool
+	}{
+		{name: "nothing is collinear with the point and the light", point: geometry.NewPoint(0, 10, 0), expected: false},
+		{name: "an object is between the point and the light", point: geometry.NewPoint(10, -10, 10), expected: true},
+		{name: "an object is behind the light", point: geometry.NewPoint(-20, 20, -20), expected: false},
+		{name: "an object is behind the point", point: geometry.NewPoint(-2, 2, -2), expected: false},
+	}
+
+	w := defaultWorld()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if result := w.IsShadowed(tt.point, w.Lights[0]); result != tt.expected {
+				t.Errorf("IsShadowed() = %v, want %v", result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestColorAt(t *testing.T) {
+	t.Run("the ray misses", func(t *testing.T) {
+		w := defaultWorld()
+		result := w.ColorAt(newRay(t, geometry.NewPoint(0, 0, -5), geometry.NewVector(0, 1, 0)))
+		if !result.Equals(canvas.Black) {
+			t.Errorf("ColorAt() = %v, want %v", result, canvas.Black)
+		}
+	})
+
+	t.Run("the ray hits", func(t *testing.T) {
+		w := defaultWorld()
+		result := w.ColorAt(newRay(t, geometry.NewPoint(0, 0, -5), geometry.NewVector(0, 0, 1)))
+		expected := canvas.NewColor(0.38066, 0.47583, 0.2855)
+		if !result.Equals(expected, 1e-4) {
+			t.Errorf("ColorAt() = %v, want %v", result, expected)
+		}
+	})
+
+	t.Run("the hit is behind the ray", func(t *testing.T) {
+		w := defaultWorld()
+		for _, object := range w.Objects {
+			m := object.Material()
+			m.Ambient = 1
+			object.SetMaterial(m)
+		}
+		inner := w.Objects[1]
+		result := w.ColorAt(newRay(t, geometry.NewPoint(0, 0, 0.75), geometry.NewVector(0, 0, -1)))
+		if !result.Equals(inner.Material().Color) {
+			t.Errorf("ColorAt() = %v, want %v", result, inner.Material().Color)
+		}
+	})
+
+	t.Run("a world without lights", func(t *testing.T) {
+		w := defaultWorld()
+		w.Lights = nil
+		result := w.ColorAt(newRay(t, geometry.NewPoint(0, 0, -5), geometry.NewVector(0, 0, 1)))
+		if !result.Equals(canvas.Black) {
+			t.Errorf("ColorAt() = %v, want %v", result, canvas.Black)
+		}
+	})
+}
+
+// reflectiveFloor adds a reflective plane below the spheres of the default world.
+func reflectiveFloor(w *World, reflective float64) shapes.Shape {
+	floor := shapes.NewPlane()
+	floor.SetTransform(geometry.Translation(0, -1, 0))
+	m := shading.DefaultMaterial()
+	m.Reflective = reflective
+	floor.SetMaterial(m)
+	w.Objects = append(w.Objects, floor)
+	return floor
+}
+
+func TestReflectedColor(t *testing.T) {
+	s := math.Sqrt2 / 2
+	tests := []struct {
+		name      string
+		remaining int
+		expected  canvas.Color
+	}{
+		{name: "reflective surface", remaining: DefaultMaxDepth, expected: canvas.NewColor(0.19033, 0.23791, 0.14274)},
+		{name: "no reflections remaining", remaining: 0, expected: canvas.Black},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := defaultWorld()
+			floor := reflectiveFloor(w, 0.5)
+			r := newRay(t, geometry.NewPoint(0, 0, -3), geometry.NewVector(0, -s, s))
+			comps := PrepareComputations(shapes.NewIntersection(math.Sqrt2, floor), r, nil)
+
+			if result := w.ReflectedColor(comps, tt.remaining); !result.Equals(tt.expected, 1e-4) {
+				t.Errorf("ReflectedColor() = %v, want %v", result, tt.expected)
+			}
+		})
+	}
+
+	t.Run("nonreflective surface", func(t *testing.T) {
+		w := defaultWorld()
+		inner := w.Objects[1]
+		m := inner.Material()
+		m.Ambient = 1
+		inner.SetMaterial(m)
+		r := newRay(t, geometry.NewPoint(0, 0, 0), geometry.NewVector(0, 0, 1))
+		comps := PrepareComputations(shapes.NewIntersection(1, inner), r, nil)
+
+		if result := w.ReflectedColor(comps, DefaultMaxDepth); !result.Equals(canvas.Black) {
+			t.Errorf("ReflectedColor() = %v, want %v", result, canvas.Black)
+		}
+	})
+}
+
+func TestShadeHitReflective(t *testing.T) {
+	s := math.Sqrt2 / 2
+	w := defaultWorld()
+	floor := reflectiveFloor(w, 0.5)
+	r := newRay(t, geometry.NewPoint(0, 0, -3), geometry.NewVector(0, -s, s))
+	comps := PrepareComputations(shapes.NewIntersection(math.Sqrt2, floor), r, nil)
+
+	expected := canvas.NewColor(0.87676, 0.92434, 0.82917)
+	if result := w.ShadeHit(comps, DefaultMaxDepth); !result.Equals(expected, 1e-4) {
+		t.Errorf("ShadeHit() = %v, want %v", result, expected)
+	}
+}
+
+func TestColorAtMutuallyReflectiveSurfaces(t *testing.T) {
+	w := NewWorld()
+	w.Lights = []shading.PointLight{shading.NewPointLight(geometry.NewPoint(0, 0, 0), canvas.White)}
+	m := shading.DefaultMaterial()
+	m.Reflective = 1
+	lower := shapes.NewPlane()
+	lower.SetMaterial(m)
+	lower.SetTransform(geometry.Translation(0, -1, 0))
+	upper := shapes.NewPlane()
+	upper.SetMaterial(m)
+	upper.SetTransform(geometry.Translation(0, 1, 0))
+	w.Objects = []shapes.Shape{lower, upper}
+
+	r := newRay(t, geometry.NewPoint(0, 0, 0), geometry.NewVector(0, 1, 0))
+	previous := canvas.Black
+	for _, maxDepth := range []int{0, 1, DefaultMaxDepth, 50} {
+		// the call must return rather than recurse forever; each bounce adds the lit color of the plane
+		result := w.ColorAt(r, RenderOptions{MaxDepth: &maxDepth})
+		if result.R() <= previous.R() {
+			t.Errorf("ColorAt() with MaxDepth %d = %v, want a lit color brighter than %v", maxDepth, result, previous)
+		}
+		previous = result
+	}
+	maxDepth := DefaultMaxDepth
+	if result, expected := w.ColorAt(r), w.ColorAt(r, RenderOptions{MaxDepth: &maxDepth}); !result.Equals(expected) {
+		t.Errorf("ColorAt() without a MaxDepth = %v, want %v", result, expected)
+	}
+}
+
+// pointPattern colors each point with its own coordinates, which shows where a ray ended up.
+type pointPattern struct{}
+
+func (pointPattern) Transform() geometry.Matrix4           { return geometry.Identity() }
+func (pointPattern) Inverse() geometry.Matrix4             { return geometry.Identity() }
+func (pointPattern) SetTransform(m geometry.Matrix4) error { return nil }
+func (pointPattern) LocalColorAt(p geometry.HomogeneousTuple) canvas.Color {
+	return canvas.NewColor(p.X(), p.Y(), p.Z())
+}
+
+// transparentWorld is the default world with its outer sphere made of glass.
+func transparentWorld() *World {
+	w := defaultWorld()
+	m := w.Objects[0].Material()
+	m.Transparency = 1
+	m.RefractiveIndex = 1.5
+	w.Objects[0].SetMaterial(m)
+	return w
+}
+
+func TestRefractedColor(t *testing.T) {
+	s := math.Sqrt2 / 2
+	tests := []struct {
+		name      string
+		world     func() *World
+		origin    geometry.HomogeneousTuple
+		direction geometry.HomogeneousTuple
+		ts        []float64
+		objects   []int
+		hit       int
+		remaining int
+		expected  canvas.Color
+	}{
+		{
+			name:      "opaque surface",
+			world:     defaultWorld,
+			origin:    geometry.NewPoint(0, 0, -5),
+			direction: geometry.NewVector(0, 0, 1),
+			ts:        []float64{4, 6},
+			objects:   []int{0, 0},
+			remaining: DefaultMaxDepth,
+			expected:  canvas.Black,
+		},
+		{
+			name:      "no refractions remaining",
+			world:     transparentWorld,
+			origin:    geometry.NewPoint(0, 0, -5),
+			direction: geometry.NewVector(0, 0, 1),
+			ts:        []float64{4, 6},
+			objects:   []int{0, 0},
+			remaining: 0,
+			expected:  canvas.Black,
+		},
+		{
+			name:      "total internal reflection",
+			world:     transparentWorld,
+			origin:    geometry.NewPoint(0, 0, s),
+			direction: geometry.NewVector(0, 1, 0),
+			ts:        []float64{-s, s},
+			objects:   []int{0, 0},
+			hit:       1,
+			remaining: DefaultMaxDepth,
+			expected:  canvas.Black,
+		},
+		{
+			name: "refracted ray",
+			world: func() *World {
+				w := defaultWorld()
+				a, b := w.Objects[0], w.Objects[1]
+				ma := a.Material()
+				ma.Ambient = 1
+				ma.Pattern = pointPattern{}
+				a.SetMaterial(ma)
+				mb := b.Material()
+				mb.Transparency = 1
+				mb.RefractiveIndex = 1.5
+				b.SetMaterial(mb)
+				return w
+			},
+			origin:    geometry.NewPoint(0, 0, 0.1),
+			direction: geometry.NewVector(0, 1, 0),
+			ts:        []float64{-0.9899, -0.4899, 0.4899, 0.9899},
+			objects:   []int{0, 1, 1, 0},
+			hit:       2,
+			remaining: DefaultMaxDepth,
+			expected:  canvas.NewColor(0, 0.99888, 0.04725),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := tt.world()
+			var xs shapes.Intersections
+			for i, x := range tt.ts {
+				xs = append(xs, shapes.NewIntersection(x, w.Objects[tt.objects[i]]))
+			}
+			comps := PrepareComputations(xs[tt.hit], newRay(t, tt.origin, tt.direction), xs)
+
+			if result := w.RefractedColor(comps, tt.remaining); !result.Equals(tt.expected, 1e-4) {
+				t.Errorf("RefractedColor() = %v, want %v", result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestShadeHitTransparent(t *testing.T) {
+	s := math.Sqrt2 / 2
+	tests := []struct {
+		name       string
+		reflective float64
+		expected   canvas.Color
+	}{
+		{name: "transparent floor", expected: canvas.NewColor(0.93642, 0.68642, 0.68642)},
+		{name: "reflective, transparent floor", reflective: 0.5, expected: canvas.NewColor(0.93391, 0.69643, 0.69243)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := defaultWorld()
+			floor := reflectiveFloor(w, tt.reflective)
+			m := floor.Material()
+			m.Transparency = 0.5
+			m.RefractiveIndex = 1.5
+			floor.SetMaterial(m)
+
+			ball := shapes.NewSphere()
+			ball.SetTransform(geometry.Translation(0, -3.5, -0.5))
+			bm := shading.DefaultMaterial()
+			bm.Color = canvas.NewColor(1, 0, 0)
+			bm.Ambient = 0.5
+			ball.SetMaterial(bm)
+			w.Objects = append(w.Objects, ball)
+
+			r := newRay(t, geometry.NewPoint(0, 0, -3), geometry.NewVector(0, -s, s))
+			xs := shapes.NewIntersections(shapes.NewIntersection(math.Sqrt2, floor))
+			comps := PrepareComputations(xs[0], r, xs)
+
+			if result := w.ShadeHit(comps, DefaultMaxDepth); !result.Equals(tt.expected, 1e-4) {
+				t.Errorf("ShadeHit() = %v, want %v", result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestIsShadowedTransparent(t *testing.T) {
+	glassOnly := func() *World {
+		w := transparentWorld()
+		w.Objects = w.Objects[:1]
+		return w
+	}
+	tests := []struct {
+		name     string
+		world    func() *World
+		options  []RenderOptions
+		expected bool
+	}{
+		{name: "transparent objects cast shadows by default", world: glassOnly, expected: true},
+		{name: "transparent objects let the light through", world: glassOnly, options: []RenderOptions{{TransparentShadows: true}}, expected: false},
+		{name: "opaque objects inside transparent ones", world: transparentWorld, options: []RenderOptions{{TransparentShadows: true}}, expected: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := tt.world()
+			if result := w.IsShadowed(geometry.NewPoint(10, -10, 10), w.Lights[0], tt.options...); result != tt.expected {
+				t.Errorf("IsShadowed() = %v, want %v", result, tt.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,44 @@
+package shading
+
+import (
+	"github.com/seanpk/go-for-rays/internal/canvas"
+	"github.com/seanpk/go-for-rays/internal/geometry"
+)
+
+// BlendedPattern averages two patterns, for example two stripe patterns at right angles to weave a plaid.
+type BlendedPattern struct {
+	basePattern
+	a, b Pattern
+}
+
+func NewBlendedPattern(a, b Pattern) *BlendedPattern {
+	return &BlendedPattern{basePattern: newBasePattern(), a: a, b: b}
+}
+
+func (p *BlendedPattern) LocalColorAt(point geometry.HomogeneousTuple) canvas.Color {
+	return blend(PatternAt(p.a, point), PatternAt(p.b, point), 0.5)
+}
+
+// PerturbedPattern jitters the points of another pattern with Perlin noise, so that its straight lines
+// and smooth gradients look organic, like marble or wood grain.
+type PerturbedPattern struct {
+	basePattern
+	pattern Pattern
+	scale   float64
+}
+
+// NewPerturbedPattern perturbs the pattern; the scale is the largest distance a point moves along each axis.
+func NewPerturbedPattern(pattern Pattern, scale float64) *PerturbedPattern {
+	return &PerturbedPattern{basePattern: newBasePattern(), pattern: pattern, scale: scale}
+}
+
+func (p *PerturbedPattern) LocalColorAt(point geometry.HomogeneousTuple) canvas.Color {
+	x, y, z := point.X(), point.Y(), point.Z()
+	// offset the noise for each axis so that the axes are jittered independently
+	jitter := geometry.NewVector(
+		Noise(x, y, z),
+		Noise(x+31.7, y+11.3, z+7.1),
+		Noise(x+5.9, y+47.3, z+23.9),
+	)
+	return PatternAt(p.pattern, point.Add(jitter.Multiply(p.scale)))
+}
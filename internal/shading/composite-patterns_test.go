@@ -0,0 +1,59 @@
+package shading
+
+import (
+	"math"
+	"testing"
+
+	"github.com/seanpk/go-for-rays/internal/canvas"
+	"github.com/seanpk/go-for-rays/internal/geometry"
+)
+
+func TestBlendedPattern(t *testing.T) {
+	red := canvas.NewColor(1, 0, 0)
+	across := NewStripePattern(NewSolidPattern(red), NewSolidPattern(canvas.White))
+	along := NewStripePattern(NewSolidPattern(red), NewSolidPattern(canvas.White))
+	along.SetTransform(geometry.RotationY(math.Pi / 2))
+	p := NewBlendedPattern(across, along)
+
+	tests := []struct {
+		point    geometry.HomogeneousTuple
+		expected canvas.Color
+	}{
+		{point: geometry.NewPoint(0.5, 0, -0.5), expected: red},
+		{point: geometry.NewPoint(1.5, 0, -0.5), expected: canvas.NewColor(1, 0.5, 0.5)},
+		{point: geometry.NewPoint(0.5, 0, 0.5), expected: canvas.NewColor(1, 0.5, 0.5)},
+		{point: geometry.NewPoint(1.5, 0, 0.5), expected: canvas.White},
+	}
+	for _, tt := range tests {
+		if c := PatternAt(p, tt.point); !c.Equals(tt.expected) {
+			t.Errorf("PatternAt(%v) = %v, want %v", tt.point, c, tt.expected)
+		}
+	}
+}
+
+func TestPerturbedPattern(t *testing.T) {
+	inner := newTestPattern()
+
+	unperturbed := NewPerturbedPattern(inner, 0)
+	point := geometry.NewPoint(0.3, 1.7, -2.2)
+	if c := PatternAt(unperturbed, point); !c.Equals(canvas.NewColor(0.3, 1.7, -2.2)) {
+		t.Errorf("PatternAt() with no perturbation = %v, want the point itself", c)
+	}
+
+	scale := 0.2
+	perturbed := NewPerturbedPattern(inner, scale)
+	c := PatternAt(perturbed, point)
+	moved := false
+	for _, d := range []float64{c.R() - point.X(), c.G() - point.Y(), c.B() - point.Z()} {
+		if math.Abs(d) > scale {
+			t.Errorf("PatternAt() moved the point by %v, want at most %v", d, scale)
+		}
+		moved = moved || d != 0
+	}
+	if !moved {
+		t.Errorf("PatternAt() = %v, want the point to be jittered", c)
+	}
+	if again := PatternAt(perturbed, point); !again.Equals(c) {
+		t.Errorf("PatternAt() = %v, then %v; want the same jitter every time", c, again)
+	}
+}
@@ -0,0 +1,52 @@
+package shading
+
+import (
+	"math"
+
+	"github.com/seanpk/go-for-rays/internal/canvas"
+	"github.com/seanpk/go-for-rays/internal/geometry"
+)
+
+// PointLight is a light source with no size that shines equally in every direction.
+type PointLight struct {
+	Position  geometry.HomogeneousTuple
+	Intensity canvas.Color
+}
+
+// NewPointLight creates a point light at the given position with the given intensity.
+func NewPointLight(position geometry.HomogeneousTuple, intensity canvas.Color) PointLight {
+	return PointLight{Position: position, Intensity: intensity}
+}
+
+// Lighting computes the color of a point on the surface of an object with the given material,
+// as lit by the light and seen from the eye; the object places the pattern of the material, if any.
+// The eye and normal vectors must be normalized. A point in shadow only gets the ambient light.
+func Lighting(material Material, object ObjectSpace, light PointLight, point, eyev, normalv geometry.HomogeneousTuple, inShadow bool) canvas.Color {
+	color := material.Color
+	if material.Pattern != nil {
+		color = PatternAtObject(material.Pattern, object, point)
+	}
+	effectiveColor := color.Hadamard(light.Intensity)
+	ambient := effectiveColor.Multiply(material.Ambient)
+	if inShadow {
+		return ambient
+	}
+
+	lightv := light.Position.Subtract(point).Normalize()
+	lightDotNormal := lightv.DotProduct(normalv)
+	if lightDotNormal < 0 {
+		return ambient // the light is on the other side of the surface
+	}
+
+	diffuse := effectiveColor.Multiply(material.Diffuse * lightDotNormal)
+
+	specular := canvas.Black
+	reflectv := lightv.Negate().Reflect(normalv)
+	reflectDotEye := reflectv.DotProduct(eyev)
+	if reflectDotEye > 0 {
+		factor := math.Pow(reflectDotEye, material.Shininess)
+		specular = light.Intensity.Multiply(material.Specular * factor)
+	}
+
+	return ambient.Add(diffuse).Add(specular)
+}
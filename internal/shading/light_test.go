@@ -0,0 +1,101 @@
+package shading
+
+import (
+	"math"
+	"testing"
+
+	"github.com/seanpk/go-for-rays/internal/canvas"
+	"github.com/seanpk/go-for-rays/internal/geometry"
+)
+
+// identitySpace is an object whose object space is the world space.
+type identitySpace struct{}
+
+func (identitySpace) WorldToObject(point geometry.HomogeneousTuple) geometry.HomogeneousTuple {
+	return point
+}
+
+func TestNewPointLight(t *testing.T) {
+	position := geometry.NewPoint(0, 0, 0)
+	light := NewPointLight(position, canvas.White)
+	if !light.Position.Equals(position) || !light.Intensity.Equals(canvas.White) {
+		t.Errorf("NewPointLight() = %+v", light)
+	}
+}
+
+func TestLighting(t *testing.T) {
+	s := math.Sqrt2 / 2
+	tests := []struct {
+		name     string
+		eyev     geometry.HomogeneousTuple
+		light    PointLight
+		inShadow bool
+		expected canvas.Color
+	}{
+		{
+			name:     "eye between the light and the surface",
+			eyev:     geometry.NewVector(0, 0, -1),
+			light:    NewPointLight(geometry.NewPoint(0, 0, -10), canvas.White),
+			expected: canvas.NewColor(1.9, 1.9, 1.9),
+		},
+		{
+			name:     "eye between light and surface, eye offset 45 degrees",
+			eyev:     geometry.NewVector(0, s, -s),
+			light:    NewPointLight(geometry.NewPoint(0, 0, -10), canvas.White),
+			expected: canvas.NewColor(1.0, 1.0, 1.0),
+		},
+		{
+			name:     "eye opposite surface, light offset 45 degrees",
+			eyev:     geometry.NewVector(0, 0, -1),
+			light:    NewPointLight(geometry.NewPoint(0, 10, -10), canvas.White),
+			expected: canvas.NewColor(0.7364, 0.7364, 0.7364),
+		},
+		{
+			name:     "eye in the path of the reflection vector",
+			eyev:     geometry.NewVector(0, -s, -s),
+			light:    NewPointLight(geometry.NewPoint(0, 10, -10), canvas.White),
+			expected: canvas.NewColor(1.6364, 1.6364, 1.6364),
+		},
+		{
+			name:     "light behind the surface",
+			eyev:     geometry.NewVector(0, 0, -1),
+			light:    NewPointLight(geometry.NewPoint(0, 0, 10), canvas.White),
+			expected: canvas.NewColor(0.1, 0.1, 0.1),
+		},
+		{
+			name:     "surface in shadow",
+			eyev:     geometry.NewVector(0, 0, -1),
+			light:    NewPointLight(geometry.NewPoint(0, 0, -10), canvas.White),
+			inShadow: true,
+			expected: canvas.NewColor(0.1, 0.1, 0.1),
+		},
+	}
+
+	m := DefaultMaterial()
+	position := geometry.NewPoint(0, 0, 0)
+	normalv := geometry.NewVector(0, 0, -1)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := Lighting(m, identitySpace{}, tt.light, position, tt.eyev, normalv, tt.inShadow)
+			if !result.Equals(tt.expected, 1e-4) {
+				t.Errorf("Lighting() = %v, want %v", result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestLightingWithPattern(t *testing.T) {
+	m := DefaultMaterial()
+	m.Pattern = NewStripePattern(NewSolidPattern(canvas.White), NewSolidPattern(canvas.Black))
+	m.Ambient, m.Diffuse, m.Specular = 1, 0, 0
+	eyev := geometry.NewVector(0, 0, -1)
+	normalv := geometry.NewVector(0, 0, -1)
+	light := NewPointLight(geometry.NewPoint(0, 0, -10), canvas.White)
+
+	if c := Lighting(m, identitySpace{}, light, geometry.NewPoint(0.9, 0, 0), eyev, normalv, false); !c.Equals(canvas.White) {
+		t.Errorf("Lighting() at x=0.9 = %v, want %v", c, canvas.White)
+	}
+	if c := Lighting(m, identitySpace{}, light, geometry.NewPoint(1.1, 0, 0), eyev, normalv, false); !c.Equals(canvas.Black) {
+		t.Errorf("Lighting() at x=1.1 = %v, want %v", c, canvas.Black)
+	}
+}
@@ -0,0 +1,30 @@
+package shading
+
+import (
+	"github.com/seanpk/go-for-rays/internal/canvas"
+)
+
+// Material describes how the surface of a shape looks, using the Phong reflection model.
+type Material struct {
+	Color           canvas.Color
+	Pattern         Pattern // when set, colors the surface in place of Color
+	Ambient         float64 // fraction of the light reflected from the environment, from 0 to 1
+	Diffuse         float64 // fraction of the light reflected from a matte surface, from 0 to 1
+	Specular        float64 // brightness of the highlight reflected from a shiny surface, from 0 to 1
+	Shininess       float64 // size of the specular highlight: the higher, the smaller and tighter
+	Reflective      float64 // fraction of the light reflected like a mirror, from 0 (matte) to 1 (perfect mirror)
+	Transparency    float64 // fraction of the light passing through the surface, from 0 (opaque) to 1
+	RefractiveIndex float64 // how much light bends entering the material: 1 for a vacuum, 1.333 for water, 1.52 for glass
+}
+
+// DefaultMaterial is a plain white surface.
+func DefaultMaterial() Material {
+	return Material{
+		Color:           canvas.White,
+		Ambient:         0.1,
+		Diffuse:         0.9,
+		Specular:        0.9,
+		Shininess:       200.0,
+		RefractiveIndex: 1.0,
+	}
+}
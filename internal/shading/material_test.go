@@ -0,0 +1,15 @@
+package shading
+
+import (
+	"testing"
+
+	"github.com/seanpk/go-for-rays/internal/canvas"
+)
+
+func TestDefaultMaterial(t *testing.T) {
+	m := DefaultMaterial()
+	if !m.Color.Equals(canvas.White) || m.Ambient != 0.1 || m.Diffuse != 0.9 || m.Specular != 0.9 || m.Shininess != 200 ||
+		m.Reflective != 0 || m.Transparency != 0 || m.RefractiveIndex != 1 {
+		t.Errorf("DefaultMaterial() = %+v", m)
+	}
+}
@@ -0,0 +1,30 @@
+package shading
+
+import (
+	"testing"
+
+	"github.com/seanpk/go-for-rays/internal/geometry"
+)
+
+func TestNoise(t *testing.T) {
+	// the value of Ken Perlin's reference implementation
+	if n := Noise(3.14, 42, 7); !geometry.IsNearTo(n, 0.13691995878400012, 1e-12) {
+		t.Errorf("Noise(3.14, 42, 7) = %v, want 0.13691995878400012", n)
+	}
+
+	if n := Noise(1, -2, 3); n != 0 {
+		t.Errorf("Noise() at whole coordinates = %v, want 0", n)
+	}
+
+	for i := range 1000 {
+		x, y, z := float64(i)*0.173, float64(i)*-0.291, float64(i)*0.057
+		if n := Noise(x, y, z); n < -1 || n > 1 {
+			t.Fatalf("Noise(%v, %v, %v) = %v, want a value from -1 to 1", x, y, z, n)
+		}
+	}
+
+	a, b := Noise(0.5, 0.5, 0.5), Noise(0.5001, 0.5, 0.5)
+	if !geometry.IsNearTo(a, b, 1e-3) {
+		t.Errorf("Noise() jumps from %v to %v over a tiny distance, want it smooth", a, b)
+	}
+}
@@ -0,0 +1,83 @@
+package shading
+
+import (
+	"github.com/seanpk/go-for-rays/internal/canvas"
+	"github.com/seanpk/go-for-rays/internal/geometry"
+)
+
+// ObjectSpace maps world points into the object space of the shape a material is on.
+// Shapes implement it, which lets patterns follow their shapes without this package depending on shapes.
+type ObjectSpace interface {
+	WorldToObject(point geometry.HomogeneousTuple) geometry.HomogeneousTuple
+}
+
+// Pattern colors a surface according to the position on it.
+// Each pattern has a transform from its pattern space to the object space of the shape it is on,
+// so that it can be moved, scaled and rotated independently of the shape.
+type Pattern interface {
+	Transform() geometry.Matrix4
+	Inverse() geometry.Matrix4
+	SetTransform(m geometry.Matrix4) error
+
+	// LocalColorAt returns the color at a point already transformed into pattern space.
+	LocalColorAt(p geometry.HomogeneousTuple) canvas.Color
+}
+
+// PatternAtObject returns the color of the pattern at a world point on the object.
+// The point is mapped into the object space, then into the pattern space.
+func PatternAtObject(pattern Pattern, object ObjectSpace, worldPoint geometry.HomogeneousTuple) canvas.Color {
+	return PatternAt(pattern, object.WorldToObject(worldPoint))
+}
+
+// PatternAt returns the color of the pattern at a point in the space the pattern is placed in by its transform:
+// the object space for the pattern of a material, or the space of the enclosing pattern for a nested pattern.
+func PatternAt(pattern Pattern, point geometry.HomogeneousTuple) canvas.Color {
+	return pattern.LocalColorAt(pattern.Inverse().MultiplyTuple(point))
+}
+
+// basePattern holds the transform shared by every pattern.
+type basePattern struct {
+	transform geometry.Matrix4
+	inverse   geometry.Matrix4
+}
+
+func newBasePattern() basePattern {
+	return basePattern{
+		transform: geometry.Identity(),
+		inverse:   geometry.Identity(),
+	}
+}
+
+func (b *basePattern) Transform() geometry.Matrix4 {
+	return b.transform
+}
+
+// Inverse returns the cached inverse of the transform.
+func (b *basePattern) Inverse() geometry.Matrix4 {
+	return b.inverse
+}
+
+// SetTransform sets the transform from pattern space to the space the pattern is placed in.
+// It returns geometry.ErrNotInvertible, and leaves the transform unchanged, if the matrix cannot be inverted.
+func (b *basePattern) SetTransform(m geometry.Matrix4) error {
+	inverse, err := m.Inverse()
+	if err != nil {
+		return err
+	}
+	b.transform, b.inverse = m, inverse
+	return nil
+}
+
+// SolidPattern is the same color everywhere; it lets a plain color take part in other patterns.
+type SolidPattern struct {
+	basePattern
+	color canvas.Color
+}
+
+func NewSolidPattern(color canvas.Color) *SolidPattern {
+	return &SolidPattern{basePattern: newBasePattern(), color: color}
+}
+
+func (p *SolidPattern) LocalColorAt(geometry.HomogeneousTuple) canvas.Color {
+	return p.color
+}
@@ -0,0 +1,98 @@
+package shading
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/seanpk/go-for-rays/internal/canvas"
+	"github.com/seanpk/go-for-rays/internal/geometry"
+)
+
+// testPattern colors each point with its own coordinates, which shows the point it was evaluated at.
+type testPattern struct {
+	basePattern
+}
+
+func newTestPattern() *testPattern {
+	return &testPattern{basePattern: newBasePattern()}
+}
+
+func (p *testPattern) LocalColorAt(point geometry.HomogeneousTuple) canvas.Color {
+	return canvas.NewColor(point.X(), point.Y(), point.Z())
+}
+
+// transformedSpace is an object placed in the world by a transform.
+type transformedSpace struct {
+	inverse geometry.Matrix4
+}
+
+func newTransformedSpace(t *testing.T, transform geometry.Matrix4) transformedSpace {
+	t.Helper()
+	inverse, err := transform.Inverse()
+	if err != nil {
+		t.Fatalf("Inverse() error = %v", err)
+	}
+	return transformedSpace{inverse: inverse}
+}
+
+func (s transformedSpace) WorldToObject(point geometry.HomogeneousTuple) geometry.HomogeneousTuple {
+	return s.inverse.MultiplyTuple(point)
+}
+
+func TestPatternTransform(t *testing.T) {
+	p := newTestPattern()
+	if !p.Transform().Equals(geometry.Identity()) {
+		t.Errorf("default Transform() = %v, want identity", p.Transform())
+	}
+
+	if err := p.SetTransform(geometry.Translation(1, 2, 3)); err != nil {
+		t.Fatalf("SetTransform() error = %v", err)
+	}
+	if !p.Transform().Equals(geometry.Translation(1, 2, 3)) {
+		t.Errorf("Transform() = %v, want %v", p.Transform(), geometry.Translation(1, 2, 3))
+	}
+
+	if err := p.SetTransform(geometry.Scaling(0, 1, 1)); !errors.Is(err, geometry.ErrNotInvertible) {
+		t.Errorf("SetTransform() error = %v, want %v", err, geometry.ErrNotInvertible)
+	}
+	if !p.Transform().Equals(geometry.Translation(1, 2, 3)) {
+		t.Errorf("Transform() after failed SetTransform() = %v, want it unchanged", p.Transform())
+	}
+}
+
+func TestPatternAtObject(t *testing.T) {
+	tests := []struct {
+		name             string
+		objectTransform  geometry.Matrix4
+		patternTransform geometry.Matrix4
+		point            geometry.HomogeneousTuple
+		expected         canvas.Color
+	}{
+		{name: "object transformation", objectTransform: geometry.Scaling(2, 2, 2), patternTransform: geometry.Identity(), point: geometry.NewPoint(2, 3, 4), expected: canvas.NewColor(1, 1.5, 2)},
+		{name: "pattern transformation", objectTransform: geometry.Identity(), patternTransform: geometry.Scaling(2, 2, 2), point: geometry.NewPoint(2, 3, 4), expected: canvas.NewColor(1, 1.5, 2)},
+		{name: "object and pattern transformations", objectTransform: geometry.Scaling(2, 2, 2), patternTransform: geometry.Translation(0.5, 1, 1.5), point: geometry.NewPoint(2.5, 3, 3.5), expected: canvas.NewColor(0.75, 0.5, 0.25)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := newTestPattern()
+			if err := p.SetTransform(tt.patternTransform); err != nil {
+				t.Fatalf("SetTransform() error = %v", err)
+			}
+			object := newTransformedSpace(t, tt.objectTransform)
+			if c := PatternAtObject(p, object, tt.point); !c.Equals(tt.expected) {
+				t.Errorf("PatternAtObject() = %v, want %v", c, tt.expected)
+			}
+		})
+	}
+}
+
+func TestSolidPattern(t *testing.T) {
+	color := canvas.NewColor(0.2, 0.4, 0.6)
+	p := NewSolidPattern(color)
+	for _, point := range []geometry.HomogeneousTuple{geometry.NewPoint(0, 0, 0), geometry.NewPoint(-3.5, 7, 100)} {
+		if c := PatternAt(p, point); !c.Equals(color) {
+			t.Errorf("PatternAt(%v) = %v, want %v", point, c, color)
+		}
+	}
+}
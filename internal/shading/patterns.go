@@ -0,0 +1,102 @@
+package shading
+
+import (
+	"math"
+
+	"github.com/seanpk/go-for-rays/internal/canvas"
+	"github.com/seanpk/go-for-rays/internal/geometry"
+)
+
+// The patterns below alternate or blend between two sub-patterns, a and b.
+// The sub-patterns are usually solid colors, but any pattern can be nested, each with its own transform.
+
+// StripePattern alternates between a and b every unit along the x axis.
+type StripePattern struct {
+	basePattern
+	a, b Pattern
+}
+
+func NewStripePattern(a, b Pattern) *StripePattern {
+	return &StripePattern{basePattern: newBasePattern(), a: a, b: b}
+}
+
+func (p *StripePattern) LocalColorAt(point geometry.HomogeneousTuple) canvas.Color {
+	if isEven(math.Floor(point.X())) {
+		return PatternAt(p.a, point)
+	}
+	return PatternAt(p.b, point)
+}
+
+// GradientPattern blends linearly from a to b along the x axis, repeating every unit.
+type GradientPattern struct {
+	basePattern
+	a, b Pattern
+}
+
+func NewGradientPattern(a, b Pattern) *GradientPattern {
+	return &GradientPattern{basePattern: newBasePattern(), a: a, b: b}
+}
+
+func (p *GradientPattern) LocalColorAt(point geometry.HomogeneousTuple) canvas.Color {
+	x := point.X()
+	return blend(PatternAt(p.a, point), PatternAt(p.b, point), x-math.Floor(x))
+}
+
+// RingPattern alternates between a and b in concentric rings around the y axis, one unit wide.
+type RingPattern struct {
+	basePattern
+	a, b Pattern
+}
+
+func NewRingPattern(a, b Pattern) *RingPattern {
+	return &RingPattern{basePattern: newBasePattern(), a: a, b: b}
+}
+
+func (p *RingPattern) LocalColorAt(point geometry.HomogeneousTuple) canvas.Color {
+	if isEven(math.Floor(math.Hypot(point.X(), point.Z()))) {
+		return PatternAt(p.a, point)
+	}
+	return PatternAt(p.b, point)
+}
+
+// CheckerPattern alternates between a and b in unit cubes, like a three-dimensional checkerboard.
+type CheckerPattern struct {
+	basePattern
+	a, b Pattern
+}
+
+func NewCheckerPattern(a, b Pattern) *CheckerPattern {
+	return &CheckerPattern{basePattern: newBasePattern(), a: a, b: b}
+}
+
+func (p *CheckerPattern) LocalColorAt(point geometry.HomogeneousTuple) canvas.Color {
+	if isEven(math.Floor(point.X()) + math.Floor(point.Y()) + math.Floor(point.Z())) {
+		return PatternAt(p.a, point)
+	}
+	return PatternAt(p.b, point)
+}
+
+// RadialGradientPattern blends linearly from a to b outward from the y axis, repeating every unit.
+type RadialGradientPattern struct {
+	basePattern
+	a, b Pattern
+}
+
+func NewRadialGradientPattern(a, b Pattern) *RadialGradientPattern {
+	return &RadialGradientPattern{basePattern: newBasePattern(), a: a, b: b}
+}
+
+func (p *RadialGradientPattern) LocalColorAt(point geometry.HomogeneousTuple) canvas.Color {
+	distance := math.Hypot(point.X(), point.Z())
+	return blend(PatternAt(p.a, point), PatternAt(p.b, point), distance-math.Floor(distance))
+}
+
+// isEven reports whether a whole number, as returned by math.Floor, is even.
+func isEven(n float64) bool {
+	return math.Mod(n, 2) == 0
+}
+
+// blend mixes two colors linearly: all of a at fraction 0, and all of b at fraction 1.
+func blend(a, b canvas.Color, fraction float64) canvas.Color {
+	return a.Add(b.Subtract(a).Multiply(fraction))
+}
@@ -0,0 +1,125 @@
+package shading
+
+import (
+	"math"
+	"testing"
+
+	"github.com/seanpk/go-for-rays/internal/canvas"
+	"github.com/seanpk/go-for-rays/internal/geometry"
+)
+
+func whiteAndBlack() (Pattern, Pattern) {
+	return NewSolidPattern(canvas.White), NewSolidPattern(canvas.Black)
+}
+
+func TestTwoPartPatterns(t *testing.T) {
+	type sample struct {
+		point    geometry.HomogeneousTuple
+		expected canvas.Color
+	}
+	white, black := canvas.White, canvas.Black
+	tests := []struct {
+		name    string
+		pattern func(a, b Pattern) Pattern
+		samples []sample
+	}{
+		{
+			name:    "stripes are constant in y",
+			pattern: func(a, b Pattern) Pattern { return NewStripePattern(a, b) },
+			samples: []sample{{geometry.NewPoint(0, 0, 0), white}, {geometry.NewPoint(0, 1, 0), white}, {geometry.NewPoint(0, 2, 0), white}},
+		},
+		{
+			name:    "stripes are constant in z",
+			pattern: func(a, b Pattern) Pattern { return NewStripePattern(a, b) },
+			samples: []sample{{geometry.NewPoint(0, 0, 0), white}, {geometry.NewPoint(0, 0, 1), white}, {geometry.NewPoint(0, 0, 2), white}},
+		},
+		{
+			name:    "stripes alternate in x",
+			pattern: func(a, b Pattern) Pattern { return NewStripePattern(a, b) },
+			samples: []sample{
+				{geometry.NewPoint(0, 0, 0), white}, {geometry.NewPoint(0.9, 0, 0), white}, {geometry.NewPoint(1, 0, 0), black},
+				{geometry.NewPoint(-0.1, 0, 0), black}, {geometry.NewPoint(-1, 0, 0), black}, {geometry.NewPoint(-1.1, 0, 0), white},
+			},
+		},
+		{
+			name:    "gradient interpolates between colors",
+			pattern: func(a, b Pattern) Pattern { return NewGradientPattern(a, b) },
+			samples: []sample{
+				{geometry.NewPoint(0, 0, 0), white}, {geometry.NewPoint(0.25, 0, 0), canvas.NewColor(0.75, 0.75, 0.75)},
+				{geometry.NewPoint(0.5, 0, 0), canvas.NewColor(0.5, 0.5, 0.5)}, {geometry.NewPoint(0.75, 0, 0), canvas.NewColor(0.25, 0.25, 0.25)},
+			},
+		},
+		{
+			name:    "ring extends in both x and z",
+			pattern: func(a, b Pattern) Pattern { return NewRingPattern(a, b) },
+			samples: []sample{
+				{geometry.NewPoint(0, 0, 0), white}, {geometry.NewPoint(1, 0, 0), black},
+				{geometry.NewPoint(0, 0, 1), black}, {geometry.NewPoint(0.708, 0, 0.708), black},
+			},
+		},
+		{
+			name:    "checkers repeat in x",
+			pattern: func(a, b Pattern) Pattern { return NewCheckerPattern(a, b) },
+			samples: []sample{{geometry.NewPoint(0, 0, 0), white}, {geometry.NewPoint(0.99, 0, 0), white}, {geometry.NewPoint(1.01, 0, 0), black}},
+		},
+		{
+			name:    "checkers repeat in y",
+			pattern: func(a, b Pattern) Pattern { return NewCheckerPattern(a, b) },
+			samples: []sample{{geometry.NewPoint(0, 0, 0), white}, {geometry.NewPoint(0, 0.99, 0), white}, {geometry.NewPoint(0, 1.01, 0), black}},
+		},
+		{
+			name:    "checkers repeat in z",
+			pattern: func(a, b Pattern) Pattern { return NewCheckerPattern(a, b) },
+			samples: []sample{{geometry.NewPoint(0, 0, 0), white}, {geometry.NewPoint(0, 0, 0.99), white}, {geometry.NewPoint(0, 0, 1.01), black}},
+		},
+		{
+			name:    "checkers on negative coordinates",
+			pattern: func(a, b Pattern) Pattern { return NewCheckerPattern(a, b) },
+			samples: []sample{{geometry.NewPoint(-0.5, 0, 0), black}, {geometry.NewPoint(-0.5, -0.5, 0), white}, {geometry.NewPoint(-1.5, 0.5, 0.5), white}},
+		},
+		{
+			name:    "radial gradient blends outward from the y axis",
+			pattern: func(a, b Pattern) Pattern { return NewRadialGradientPattern(a, b) },
+			samples: []sample{
+				{geometry.NewPoint(0, 5, 0), white}, {geometry.NewPoint(0.5, 0, 0), canvas.NewColor(0.5, 0.5, 0.5)},
+				{geometry.NewPoint(0, 0, 0.25), canvas.NewColor(0.75, 0.75, 0.75)}, {geometry.NewPoint(0.6, 0, 0.8), white},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := tt.pattern(whiteAndBlack())
+			for _, s := range tt.samples {
+				if c := PatternAt(p, s.point); !c.Equals(s.expected, 1e-9) {
+					t.Errorf("PatternAt(%v) = %v, want %v", s.point, c, s.expected)
+				}
+			}
+		})
+	}
+}
+
+func TestNestedPatterns(t *testing.T) {
+	// checkers of stripes: the stripes in the second squares are rotated a quarter turn, and use their own transform
+	red, green, blue := canvas.NewColor(1, 0, 0), canvas.NewColor(0, 1, 0), canvas.NewColor(0, 0, 1)
+	horizontal := NewStripePattern(NewSolidPattern(red), NewSolidPattern(green))
+	horizontal.SetTransform(geometry.Scaling(0.25, 1, 1))
+	vertical := NewStripePattern(NewSolidPattern(blue), NewSolidPattern(canvas.White))
+	vertical.SetTransform(geometry.Scaling(0.25, 1, 1).RotateY(math.Pi / 2))
+	p := NewCheckerPattern(horizontal, vertical)
+
+	tests := []struct {
+		point    geometry.HomogeneousTuple
+		expected canvas.Color
+	}{
+		{point: geometry.NewPoint(0.1, 0, 0.5), expected: red},
+		{point: geometry.NewPoint(0.3, 0, 0.5), expected: green},
+		{point: geometry.NewPoint(1.5, 0, 0.1), expected: canvas.White},
+		{point: geometry.NewPoint(1.5, 0, 0.3), expected: blue},
+	}
+	for _, tt := range tests {
+		if c := PatternAt(p, tt.point); !c.Equals(tt.expected) {
+			t.Errorf("PatternAt(%v) = %v, want %v", tt.point, c, tt.expected)
+		}
+	}
+}
@@ -0,0 +1,66 @@
+package shapes
+
+import (
+	"math"
+
+	"github.com/seanpk/go-for-rays/internal/geometry"
+	"github.com/seanpk/go-for-rays/internal/ray"
+)
+
+// Cube is the axis-aligned cube of its object space, from -1 to 1 on each axis.
+// Its transform places it in the world, for example as a room, a table top or a box.
+type Cube struct {
+	baseShape
+}
+
+// NewCube creates a cube centered on the world origin, two units on a side.
+func NewCube() *Cube {
+	return &Cube{baseShape: newBaseShape()}
+}
+
+// LocalIntersect returns where the ray enters and leaves the cube, using the slab method:
+// the cube is the overlap of the slabs between each pair of opposite faces,
+// so the ray is inside it from the last slab it enters until the first slab it leaves.
+func (c *Cube) LocalIntersect(r ray.Ray) Intersections {
+	origin, direction := r.Origin(), r.Direction()
+	xMin, xMax := slabIntersect(origin.X(), direction.X())
+	yMin, yMax := slabIntersect(origin.Y(), direction.Y())
+	zMin, zMax := slabIntersect(origin.Z(), direction.Z())
+
+	tMin := max(xMin, yMin, zMin)
+	tMax := min(xMax, yMax, zMax)
+	if tMin > tMax {
+		return nil
+	}
+	return Intersections{NewIntersection(tMin, c), NewIntersection(tMax, c)}
+}
+
+// slabIntersect returns where a ray, along one axis, crosses the faces of the cube at -1 and 1, nearest first.
+// A ray parallel to the faces is within the slab everywhere if it starts between them, and nowhere otherwise.
+func slabIntersect(origin, direction float64) (tMin, tMax float64) {
+	if math.Abs(direction) < geometry.EPSILON {
+		if origin < -1 || origin > 1 {
+			return math.Inf(1), math.Inf(-1)
+		}
+		return math.Inf(-1), math.Inf(1)
+	}
+	tMin, tMax = (-1-origin)/direction, (1-origin)/direction
+	if tMin > tMax {
+		tMin, tMax = tMax, tMin
+	}
+	return tMin, tMax
+}
+
+// LocalNormalAt returns the normal of the face the point is on, along the axis of its largest component.
+// At an edge or a corner, the x face wins over the y face, and the y face over the z face.
+func (c *Cube) LocalNormalAt(point geometry.HomogeneousTuple) geometry.HomogeneousTuple {
+	x, y, z := point.X(), point.Y(), point.Z()
+	switch max(math.Abs(x), math.Abs(y), math.Abs(z)) {
+	case math.Abs(x):
+		return geometry.NewVector(math.Copysign(1, x), 0, 0)
+	case math.Abs(y):
+		return geometry.NewVector(0, math.Copysign(1, y), 0)
+	default:
+		return geometry.NewVector(0, 0, math.Copysign(1, z))
+	}
+}
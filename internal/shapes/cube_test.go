@@ -0,0 +1,78 @@
+package shapes
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/seanpk/go-for-rays/internal/geometry"
+)
+
+func TestCubeIntersect(t *testing.T) {
+	tests := []struct {
+		name      string
+		origin    geometry.HomogeneousTuple
+		direction geometry.HomogeneousTuple
+		expected  []float64
+	}{
+		{name: "+x face", origin: geometry.NewPoint(5, 0.5, 0), direction: geometry.NewVector(-1, 0, 0), expected: []float64{4, 6}},
+		{name: "-x face", origin: geometry.NewPoint(-5, 0.5, 0), direction: geometry.NewVector(1, 0, 0), expected: []float64{4, 6}},
+		{name: "+y face", origin: geometry.NewPoint(0.5, 5, 0), direction: geometry.NewVector(0, -1, 0), expected: []float64{4, 6}},
+		{name: "-y face", origin: geometry.NewPoint(0.5, -5, 0), direction: geometry.NewVector(0, 1, 0), expected: []float64{4, 6}},
+		{name: "+z face", origin: geometry.NewPoint(0.5, 0, 5), direction: geometry.NewVector(0, 0, -1), expected: []float64{4, 6}},
+		{name: "-z face", origin: geometry.NewPoint(0.5, 0, -5), direction: geometry.NewVector(0, 0, 1), expected: []float64{4, 6}},
+		{name: "inside", origin: geometry.NewPoint(0, 0.5, 0), direction: geometry.NewVector(0, 0, 1), expected: []float64{-1, 1}},
+		{name: "diagonal miss", origin: geometry.NewPoint(-2, 0, 0), direction: geometry.NewVector(0.2673, 0.5345, 0.8018), expected: nil},
+		{name: "diagonal miss in y", origin: geometry.NewPoint(0, -2, 0), direction: geometry.NewVector(0.8018, 0.2673, 0.5345), expected: nil},
+		{name: "diagonal miss in z", origin: geometry.NewPoint(0, 0, -2), direction: geometry.NewVector(0.5345, 0.8018, 0.2673), expected: nil},
+		{name: "parallel miss beside x", origin: geometry.NewPoint(2, 0, 2), direction: geometry.NewVector(0, 0, -1), expected: nil},
+		{name: "parallel miss above", origin: geometry.NewPoint(0, 2, 2), direction: geometry.NewVector(0, -1, 0), expected: nil},
+		{name: "parallel miss beside z", origin: geometry.NewPoint(2, 2, 0), direction: geometry.NewVector(-1, 0, 0), expected: nil},
+		{name: "along a face", origin: geometry.NewPoint(1, 0, -5), direction: geometry.NewVector(0, 0, 1), expected: []float64{4, 6}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := NewCube()
+			xs := Intersect(c, newRay(t, tt.origin, tt.direction))
+			if !slices.EqualFunc(xs, tt.expected, func(x Intersection, t float64) bool { return geometry.IsNearTo(x.T, t) }) {
+				t.Errorf("Intersect() = %v, want t values %v", xs, tt.expected)
+			}
+			for _, x := range xs {
+				if x.Object != c {
+					t.Errorf("Intersect() object = %v, want the cube", x.Object)
+				}
+			}
+		})
+	}
+}
+
+func TestCubeNormalAt(t *testing.T) {
+	tests := []struct {
+		name      string
+		transform geometry.Matrix4
+		point     geometry.HomogeneousTuple
+		expected  geometry.HomogeneousTuple
+	}{
+		{name: "+x face", transform: geometry.Identity(), point: geometry.NewPoint(1, 0.5, -0.8), expected: geometry.NewVector(1, 0, 0)},
+		{name: "-x face", transform: geometry.Identity(), point: geometry.NewPoint(-1, -0.2, 0.9), expected: geometry.NewVector(-1, 0, 0)},
+		{name: "+y face", transform: geometry.Identity(), point: geometry.NewPoint(-0.4, 1, -0.1), expected: geometry.NewVector(0, 1, 0)},
+		{name: "-y face", transform: geometry.Identity(), point: geometry.NewPoint(0.3, -1, -0.7), expected: geometry.NewVector(0, -1, 0)},
+		{name: "+z face", transform: geometry.Identity(), point: geometry.NewPoint(-0.6, 0.3, 1), expected: geometry.NewVector(0, 0, 1)},
+		{name: "-z face", transform: geometry.Identity(), point: geometry.NewPoint(0.4, 0.4, -1), expected: geometry.NewVector(0, 0, -1)},
+		{name: "corner", transform: geometry.Identity(), point: geometry.NewPoint(1, 1, 1), expected: geometry.NewVector(1, 0, 0)},
+		{name: "opposite corner", transform: geometry.Identity(), point: geometry.NewPoint(-1, -1, -1), expected: geometry.NewVector(-1, 0, 0)},
+		{name: "scaled into a table top", transform: geometry.Scaling(2, 0.1, 1), point: geometry.NewPoint(0.5, 0.1, 0.5), expected: geometry.NewVector(0, 1, 0)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := NewCube()
+			if err := c.SetTransform(tt.transform); err != nil {
+				t.Fatalf("SetTransform() error = %v", err)
+			}
+			if got := NormalAt(c, tt.point); !got.Equals(tt.expected) {
+				t.Errorf("NormalAt() = %v, want %v", got, tt.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,53 @@
+package shapes
+
+import (
+	"sort"
+)
+
+// Intersection records where, as a distance t along a ray, the ray meets a shape.
+type Intersection struct {
+	T      float64
+	Object Shape
+}
+
+func NewIntersection(t float64, object Shape) Intersection {
+	return Intersection{T: t, Object: object}
+}
+
+// Intersections is a list of intersections sorted by increasing t.
+type Intersections []Intersection
+
+// NewIntersections creates a sorted list from the given intersections.
+func NewIntersections(xs ...Intersection) Intersections {
+	sorted := Intersections(append([]Intersection(nil), xs...))
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].T < sorted[j].T })
+	return sorted
+}
+
+// Merge returns a new sorted list holding the intersections of both lists.
+func (xs Intersections) Merge(other Intersections) Intersections {
+	merged := make(Intersections, 0, len(xs)+len(other))
+	i, j := 0, 0
+	for i < len(xs) && j < len(other) {
+		if other[j].T < xs[i].T {
+			merged = append(merged, other[j])
+			j++
+		} else {
+			merged = append(merged, xs[i])
+			i++
+		}
+	}
+	merged = append(merged, xs[i:]...)
+	return append(merged, other[j:]...)
+}
+
+// Hit returns the visible intersection: the one with the lowest non-negative t.
+// It returns false if every intersection is behind the ray's origin.
+func (xs Intersections) Hit() (Intersection, bool) {
+	for _, x := range xs {
+		if x.T >= 0 {
+			return x, true
+		}
+	}
+	return Intersection{}, false
+}
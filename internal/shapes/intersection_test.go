@@ -0,0 +1,57 @@
+package shapes
+
+import (
+	"testing"
+)
+
+func TestNewIntersectionsSorts(t *testing.T) {
+	s := NewSphere()
+	xs := NewIntersections(NewIntersection(5, s), NewIntersection(-3, s), NewIntersection(2, s))
+	for i, expected := range []float64{-3, 2, 5} {
+		if xs[i].T != expected {
+			t.Errorf("xs[%d].T = %v, want %v", i, xs[i].T, expected)
+		}
+	}
+}
+
+func TestMerge(t *testing.T) {
+	s := NewSphere()
+	a := NewIntersections(NewIntersection(1, s), NewIntersection(4, s))
+	b := NewIntersections(NewIntersection(-1, s), NewIntersection(2, s), NewIntersection(6, s))
+
+	merged := a.Merge(b)
+	for i, expected := range []float64{-1, 1, 2, 4, 6} {
+		if merged[i].T != expected {
+			t.Errorf("merged[%d].T = %v, want %v", i, merged[i].T, expected)
+		}
+	}
+}
+
+func TestHit(t *testing.T) {
+	s := NewSphere()
+	tests := []struct {
+		name     string
+		xs       Intersections
+		expected float64
+		ok       bool
+	}{
+		{name: "all positive", xs: NewIntersections(NewIntersection(1, s), NewIntersection(2, s)), expected: 1, ok: true},
+		{name: "some negative", xs: NewIntersections(NewIntersection(-1, s), NewIntersection(1, s)), expected: 1, ok: true},
+		{name: "zero counts", xs: NewIntersections(NewIntersection(-1, s), NewIntersection(0, s)), expected: 0, ok: true},
+		{name: "all negative", xs: NewIntersections(NewIntersection(-2, s), NewIntersection(-1, s)), ok: false},
+		{name: "lowest non-negative", xs: NewIntersections(NewIntersection(5, s), NewIntersection(7, s), NewIntersection(-3, s), NewIntersection(2, s)), expected: 2, ok: true},
+		{name: "none", xs: nil, ok: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hit, ok := tt.xs.Hit()
+			if ok != tt.ok {
+				t.Fatalf("Hit() ok = %v, want %v", ok, tt.ok)
+			}
+			if ok && hit.T != tt.expected {
+				t.Errorf("Hit().T = %v, want %v", hit.T, tt.expected)
+			}
+		})
+	}
+}
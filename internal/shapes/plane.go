@@ -0,0 +1,31 @@
+package shapes
+
+import (
+	"github.com/seanpk/go-for-rays/internal/geometry"
+	"github.com/seanpk/go-for-rays/internal/ray"
+)
+
+// Plane is the infinite xz plane of its object space, facing up the y axis.
+// Its transform places it in the world, for example as a floor or a wall.
+type Plane struct {
+	baseShape
+}
+
+// NewPlane creates a plane through the world origin, facing up.
+func NewPlane() *Plane {
+	return &Plane{baseShape: newBaseShape()}
+}
+
+// LocalIntersect returns where the ray crosses the plane.
+// A ray parallel to the plane, including one within it, returns no intersections.
+func (p *Plane) LocalIntersect(r ray.Ray) Intersections {
+	if geometry.IsNearTo(r.Direction().Y(), 0, geometry.EPSILON) {
+		return nil
+	}
+	return Intersections{NewIntersection(-r.Origin().Y()/r.Direction().Y(), p)}
+}
+
+// LocalNormalAt is the same everywhere on the plane.
+func (p *Plane) LocalNormalAt(geometry.HomogeneousTuple) geometry.HomogeneousTuple {
+	return geometry.NewVector(0, 1, 0)
+}
@@ -0,0 +1,71 @@
+package shapes
+
+import (
+	"math"
+	"slices"
+	"testing"
+
+	"github.com/seanpk/go-for-rays/internal/geometry"
+)
+
+func TestPlaneIntersect(t *testing.T) {
+	tests := []struct {
+		name      string
+		transform geometry.Matrix4
+		origin    geometry.HomogeneousTuple
+		direction geometry.HomogeneousTuple
+		expected  []float64
+	}{
+		{name: "ray parallel to the plane", transform: geometry.Identity(), origin: geometry.NewPoint(0, 10, 0), direction: geometry.NewVector(0, 0, 1), expected: nil},
+		{name: "coplanar ray", transform: geometry.Identity(), origin: geometry.NewPoint(0, 0, 0), direction: geometry.NewVector(0, 0, 1), expected: nil},
+		{name: "nearly parallel ray", transform: geometry.Identity(), origin: geometry.NewPoint(0, 1, 0), direction: geometry.NewVector(0, 1e-9, 1), expected: nil},
+		{name: "ray from above", transform: geometry.Identity(), origin: geometry.NewPoint(0, 1, 0), direction: geometry.NewVector(0, -1, 0), expected: []float64{1}},
+		{name: "ray from below", transform: geometry.Identity(), origin: geometry.NewPoint(0, -1, 0), direction: geometry.NewVector(0, 1, 0), expected: []float64{1}},
+		{name: "slanted ray", transform: geometry.Identity(), origin: geometry.NewPoint(0, 2, -2), direction: geometry.NewVector(0, -1, 1), expected: []float64{2}},
+		{name: "wall", transform: geometry.RotationX(math.Pi/2).Translate(0, 0, 5), origin: geometry.NewPoint(0, 0, 0), direction: geometry.NewVector(0, 0, 1), expected: []float64{5}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := NewPlane()
+			if err := p.SetTransform(tt.transform); err != nil {
+				t.Fatalf("SetTransform() error = %v", err)
+			}
+			xs := Intersect(p, newRay(t, tt.origin, tt.direction))
+			if !slices.EqualFunc(xs, tt.expected, func(x Intersection, t float64) bool { return geometry.IsNearTo(x.T, t) }) {
+				t.Errorf("Intersect() = %v, want t values %v", xs, tt.expected)
+			}
+			for _, x := range xs {
+				if x.Object != p {
+					t.Errorf("Intersect() object = %v, want the plane", x.Object)
+				}
+			}
+		})
+	}
+}
+
+func TestPlaneNormalAt(t *testing.T) {
+	tests := []struct {
+		name      string
+		transform geometry.Matrix4
+		point     geometry.HomogeneousTuple
+		expected  geometry.HomogeneousTuple
+	}{
+		{name: "at the origin", transform: geometry.Identity(), point: geometry.NewPoint(0, 0, 0), expected: geometry.NewVector(0, 1, 0)},
+		{name: "away from the origin", transform: geometry.Identity(), point: geometry.NewPoint(10, 0, -10), expected: geometry.NewVector(0, 1, 0)},
+		{name: "far away", transform: geometry.Identity(), point: geometry.NewPoint(-5, 0, 150), expected: geometry.NewVector(0, 1, 0)},
+		{name: "wall", transform: geometry.RotationX(math.Pi/2).Translate(0, 0, 5), point: geometry.NewPoint(3, 2, 5), expected: geometry.NewVector(0, 0, 1)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := NewPlane()
+			if err := p.SetTransform(tt.transform); err != nil {
+				t.Fatalf("SetTransform() error = %v", err)
+			}
+			if got := NormalAt(p, tt.point); !got.Equals(tt.expected) {
+				t.Errorf("NormalAt() = %v, want %v", got, tt.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,108 @@
+package shapes
+
+import (
+	"github.com/seanpk/go-for-rays/internal/geometry"
+	"github.com/seanpk/go-for-rays/internal/ray"
+	"github.com/seanpk/go-for-rays/internal/shading"
+)
+
+// Shape is a primitive (or a group of primitives) that rays can intersect.
+// Each shape has a transform from its object space to its parent's space (the world for top-level shapes).
+// Primitives embed baseShape for the common state, and only implement the two local methods,
+// which work in object space; Intersect and NormalAt take care of the transformations.
+type Shape interface {
+	Transform() geometry.Matrix4
+	Inverse() geometry.Matrix4
+	SetTransform(m geometry.Matrix4) error
+	Material() shading.Material
+	SetMaterial(m shading.Material)
+	Parent() Shape
+	SetParent(parent Shape)
+	// WorldToObject maps a world point into the object space of the shape, through the spaces of its parents.
+	// It makes every shape a shading.ObjectSpace, so that patterns follow the shapes they are on.
+	WorldToObject(point geometry.HomogeneousTuple) geometry.HomogeneousTuple
+
+	// LocalIntersect returns the intersections of a ray already transformed into object space.
+	LocalIntersect(r ray.Ray) Intersections
+	// LocalNormalAt returns the (not necessarily normalized) normal at a point in object space.
+	LocalNormalAt(p geometry.HomogeneousTuple) geometry.HomogeneousTuple
+}
+
+// baseShape holds the state shared by every shape.
+type baseShape struct {
+	transform geometry.Matrix4
+	inverse   geometry.Matrix4
+	material  shading.Material
+	parent    Shape
+}
+
+func newBaseShape() baseShape {
+	return baseShape{
+		transform: geometry.Identity(),
+		inverse:   geometry.Identity(),
+		material:  shading.DefaultMaterial(),
+	}
+}
+
+func (b *baseShape) Transform() geometry.Matrix4 {
+	return b.transform
+}
+
+// Inverse returns the cached inverse of the transform.
+func (b *baseShape) Inverse() geometry.Matrix4 {
+	return b.inverse
+}
+
+// SetTransform sets the transform from object space to the parent's space.
+// It returns geometry.ErrNotInvertible, and leaves the transform unchanged, if the matrix cannot be inverted.
+func (b *baseShape) SetTransform(m geometry.Matrix4) error {
+	inverse, err := m.Inverse()
+	if err != nil {
+		return err
+	}
+	b.transform, b.inverse = m, inverse
+	return nil
+}
+
+func (b *baseShape) Material() shading.Material {
+	return b.material
+}
+
+func (b *baseShape) SetMaterial(m shading.Material) {
+	b.material = m
+}
+
+func (b *baseShape) Parent() Shape {
+	return b.parent
+}
+
+func (b *baseShape) SetParent(parent Shape) {
+	b.parent = parent
+}
+
+func (b *baseShape) WorldToObject(point geometry.HomogeneousTuple) geometry.HomogeneousTuple {
+	if b.parent != nil {
+		point = b.parent.WorldToObject(point)
+	}
+	return b.inverse.MultiplyTuple(point)
+}
+
+// Intersect returns the intersections of a world-space ray with the shape, sorted by distance.
+func Intersect(s Shape, r ray.Ray) Intersections {
+	return NewIntersections(s.LocalIntersect(r.Transform(s.Inverse()))...)
+}
+
+// NormalAt returns the unit surface normal of the shape at a point given in world space.
+func NormalAt(s Shape, worldPoint geometry.HomogeneousTuple) geometry.HomogeneousTuple {
+	return NormalToWorld(s, s.LocalNormalAt(s.WorldToObject(worldPoint)))
+}
+
+// NormalToWorld maps a normal from the object space of the shape into world space, through the spaces of its parents.
+// Normals are transformed by the transpose of the inverse transform, which keeps them perpendicular to the surface.
+func NormalToWorld(s Shape, normal geometry.HomogeneousTuple) geometry.HomogeneousTuple {
+	normal = geometry.ToVector(s.Inverse().Transpose().MultiplyTuple(normal)).Normalize()
+	if parent := s.Parent(); parent != nil {
+		normal = NormalToWorld(parent, normal)
+	}
+	return normal
+}
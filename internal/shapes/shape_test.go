@@ -0,0 +1,120 @@
+package shapes
+
+import (
+	"math"
+	"testing"
+
+	"github.com/seanpk/go-for-rays/internal/canvas"
+	"github.com/seanpk/go-for-rays/internal/geometry"
+	"github.com/seanpk/go-for-rays/internal/ray"
+	"github.com/seanpk/go-for-rays/internal/shading"
+)
+
+// testShape records the ray it was asked to intersect, and uses the object point as its normal.
+type testShape struct {
+	baseShape
+	savedRay ray.Ray
+}
+
+func newTestShape() *testShape {
+	return &testShape{baseShape: newBaseShape()}
+}
+
+func (s *testShape) LocalIntersect(r ray.Ray) Intersections {
+	s.savedRay = r
+	return nil
+}
+
+func (s *testShape) LocalNormalAt(p geometry.HomogeneousTuple) geometry.HomogeneousTuple {
+	return geometry.ToVector(p)
+}
+
+func TestShapeDefaults(t *testing.T) {
+	s := newTestShape()
+	if !s.Transform().Equals(geometry.Identity()) {
+		t.Errorf("Transform() = %v, want identity", s.Transform())
+	}
+	if s.Material() != shading.DefaultMaterial() {
+		t.Errorf("Material() = %v, want the default material", s.Material())
+	}
+	if s.Parent() != nil {
+		t.Errorf("Parent() = %v, want nil", s.Parent())
+	}
+
+	m := shading.DefaultMaterial()
+	m.Color = canvas.NewColor(1, 0, 0)
+	s.SetMaterial(m)
+	if s.Material() != m {
+		t.Errorf("Material() = %v, want %v", s.Material(), m)
+	}
+}
+
+func TestIntersectTransformsTheRay(t *testing.T) {
+	tests := []struct {
+		name      string
+		transform geometry.Matrix4
+		origin    geometry.HomogeneousTuple
+		direction geometry.HomogeneousTuple
+	}{
+		{name: "scaled shape", transform: geometry.Scaling(2, 2, 2), origin: geometry.NewPoint(0, 0, -2.5), direction: geometry.NewVector(0, 0, 0.5)},
+		{name: "translated shape", transform: geometry.Translation(5, 0, 0), origin: geometry.NewPoint(-5, 0, -5), direction: geometry.NewVector(0, 0, 1)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newTestShape()
+			s.SetTransform(tt.transform)
+			Intersect(s, newRay(t, geometry.NewPoint(0, 0, -5), geometry.NewVector(0, 0, 1)))
+			if !s.savedRay.Origin().Equals(tt.origin) || !s.savedRay.Direction().Equals(tt.direction) {
+				t.Errorf("local ray = %v, want Ray(%v, %v)", s.savedRay, tt.origin, tt.direction)
+			}
+		})
+	}
+}
+
+func TestNormalAtTransformsTheNormal(t *testing.T) {
+	tests := []struct {
+		name      string
+		transform geometry.Matrix4
+		point     geometry.HomogeneousTuple
+		expected  geometry.HomogeneousTuple
+	}{
+		{name: "translated shape", transform: geometry.Translation(0, 1, 0), point: geometry.NewPoint(0, 1.70711, -0.70711), expected: geometry.NewVector(0, 0.70711, -0.70711)},
+		{name: "transformed shape", transform: geometry.Identity().RotateZ(math.Pi/5).Scale(1, 0.5, 1), point: geometry.NewPoint(0, math.Sqrt2/2, -math.Sqrt2/2), expected: geometry.NewVector(0, 0.97014, -0.24254)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newTestShape()
+			s.SetTransform(tt.transform)
+			if got := NormalAt(s, tt.point); !got.Equals(tt.expected, 1e-5) {
+				t.Errorf("NormalAt() = %v, want %v", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestParentTransforms(t *testing.T) {
+	nested := func(middleTransform geometry.Matrix4) *Sphere {
+		outer := newTestShape()
+		outer.SetTransform(geometry.RotationY(math.Pi / 2))
+		middle := newTestShape()
+		middle.SetTransform(middleTransform)
+		middle.SetParent(outer)
+		s := NewSphere()
+		s.SetTransform(geometry.Translation(5, 0, 0))
+		s.SetParent(middle)
+		return s
+	}
+
+	s := nested(geometry.Scaling(2, 2, 2))
+	if got, want := s.WorldToObject(geometry.NewPoint(-2, 0, -10)), geometry.NewPoint(0, 0, -1); !got.Equals(want) {
+		t.Errorf("WorldToObject() = %v, want %v", got, want)
+	}
+
+	s = nested(geometry.Scaling(1, 2, 3))
+	third := math.Sqrt(3) / 3
+	if got, want := NormalToWorld(s, geometry.NewVector(third, third, third)), geometry.NewVector(0.2857, 0.4286, -0.8571); !got.Equals(want, 1e-4) {
+		t.Errorf("NormalToWorld() = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,45 @@
+package shapes
+
+import (
+	"math"
+
+	"github.com/seanpk/go-for-rays/internal/geometry"
+	"github.com/seanpk/go-for-rays/internal/ray"
+)
+
+// Sphere is a unit sphere centered on the origin of its object space.
+// Its transform places it in the world.
+type Sphere struct {
+	baseShape
+}
+
+// NewSphere creates a unit sphere at the world origin.
+func NewSphere() *Sphere {
+	return &Sphere{baseShape: newBaseShape()}
+}
+
+// LocalIntersect returns where the ray meets the unit sphere.
+// A ray that misses returns no intersections; a tangent ray returns the same intersection twice.
+func (s *Sphere) LocalIntersect(r ray.Ray) Intersections {
+	sphereToRay := r.Origin().Subtract(geometry.NewPoint(0, 0, 0))
+
+	a := r.Direction().DotProduct(r.Direction())
+	b := 2 * r.Direction().DotProduct(sphereToRay)
+	c := sphereToRay.DotProduct(sphereToRay) - 1
+
+	discriminant := b*b - 4*a*c
+	if discriminant < 0 {
+		return nil
+	}
+
+	root := math.Sqrt(discriminant)
+	return Intersections{
+		NewIntersection((-b-root)/(2*a), s),
+		NewIntersection((-b+root)/(2*a), s),
+	}
+}
+
+// LocalNormalAt points away from the center of the sphere.
+func (s *Sphere) LocalNormalAt(p geometry.HomogeneousTuple) geometry.HomogeneousTuple {
+	return p.Subtract(geometry.NewPoint(0, 0, 0))
+}
@@ -0,0 +1,102 @@
+package shapes
+
+import (
+	"errors"
+	"math"
+	"slices"
+	"testing"
+
+	"github.com/seanpk/go-for-rays/internal/geometry"
+	"github.com/seanpk/go-for-rays/internal/ray"
+)
+
+func newRay(t *testing.T, origin, direction geometry.HomogeneousTuple) ray.Ray {
+	t.Helper()
+	r, err := ray.New(origin, direction)
+	if err != nil {
+		t.Fatalf("ray.New() error = %v", err)
+	}
+	return r
+}
+
+func TestSphereIntersect(t *testing.T) {
+	tests := []struct {
+		name      string
+		transform geometry.Matrix4
+		origin    geometry.HomogeneousTuple
+		expected  []float64
+	}{
+		{name: "two points", transform: geometry.Identity(), origin: geometry.NewPoint(0, 0, -5), expected: []float64{4, 6}},
+		{name: "tangent", transform: geometry.Identity(), origin: geometry.NewPoint(0, 1, -5), expected: []float64{5, 5}},
+		{name: "miss", transform: geometry.Identity(), origin: geometry.NewPoint(0, 2, -5), expected: nil},
+		{name: "ray inside the sphere", transform: geometry.Identity(), origin: geometry.NewPoint(0, 0, 0), expected: []float64{-1, 1}},
+		{name: "sphere behind the ray", transform: geometry.Identity(), origin: geometry.NewPoint(0, 0, 5), expected: []float64{-6, -4}},
+		{name: "scaled sphere", transform: geometry.Scaling(2, 2, 2), origin: geometry.NewPoint(0, 0, -5), expected: []float64{3, 7}},
+		{name: "translated sphere", transform: geometry.Translation(5, 0, 0), origin: geometry.NewPoint(0, 0, -5), expected: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := NewSphere()
+			if err := s.SetTransform(tt.transform); err != nil {
+				t.Fatalf("SetTransform() error = %v", err)
+			}
+			xs := Intersect(s, newRay(t, tt.origin, geometry.NewVector(0, 0, 1)))
+			if !slices.EqualFunc(xs, tt.expected, func(x Intersection, t float64) bool { return geometry.IsNearTo(x.T, t) }) {
+				t.Errorf("Intersect() = %v, want t values %v", xs, tt.expected)
+			}
+			for _, x := range xs {
+				if x.Object != s {
+					t.Errorf("Intersect() object = %v, want the sphere", x.Object)
+				}
+			}
+		})
+	}
+}
+
+func TestSphereSetTransform(t *testing.T) {
+	s := NewSphere()
+	if !s.Transform().Equals(geometry.Identity()) {
+		t.Errorf("default Transform() = %v, want identity", s.Transform())
+	}
+
+	if err := s.SetTransform(geometry.Scaling(1, 0, 1)); !errors.Is(err, geometry.ErrNotInvertible) {
+		t.Errorf("SetTransform() error = %v, want %v", err, geometry.ErrNotInvertible)
+	}
+	if !s.Transform().Equals(geometry.Identity()) {
+		t.Errorf("Transform() after failed SetTransform() = %v, want identity", s.Transform())
+	}
+}
+
+func TestSphereNormalAt(t *testing.T) {
+	third := math.Sqrt(3) / 3
+	tests := []struct {
+		name      string
+		transform geometry.Matrix4
+		point     geometry.HomogeneousTuple
+		expected  geometry.HomogeneousTuple
+	}{
+		{name: "on the x axis", transform: geometry.Identity(), point: geometry.NewPoint(1, 0, 0), expected: geometry.NewVector(1, 0, 0)},
+		{name: "on the y axis", transform: geometry.Identity(), point: geometry.NewPoint(0, 1, 0), expected: geometry.NewVector(0, 1, 0)},
+		{name: "on the z axis", transform: geometry.Identity(), point: geometry.NewPoint(0, 0, 1), expected: geometry.NewVector(0, 0, 1)},
+		{name: "nonaxial", transform: geometry.Identity(), point: geometry.NewPoint(third, third, third), expected: geometry.NewVector(third, third, third)},
+		{name: "translated", transform: geometry.Translation(0, 1, 0), point: geometry.NewPoint(0, 1.70711, -0.70711), expected: geometry.NewVector(0, 0.70711, -0.70711)},
+		{name: "transformed", transform: geometry.Identity().RotateZ(math.Pi/5).Scale(1, 0.5, 1), point: geometry.NewPoint(0, math.Sqrt2/2, -math.Sqrt2/2), expected: geometry.NewVector(0, 0.97014, -0.24254)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := NewSphere()
+			if err := s.SetTransform(tt.transform); err != nil {
+				t.Fatalf("SetTransform() error = %v", err)
+			}
+			got := NormalAt(s, tt.point)
+			if !got.Equals(tt.expected, 1e-5) {
+				t.Errorf("NormalAt() = %v, want %v", got, tt.expected)
+			}
+			if !geometry.IsNearTo(got.Magnitude(), 1) {
+				t.Errorf("NormalAt() magnitude = %v, want 1", got.Magnitude())
+			}
+		})
+	}
+}